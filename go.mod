@@ -4,6 +4,7 @@ go 1.16
 
 require (
 	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
+	github.com/hashicorp/terraform-plugin-go v0.5.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.10.0
 	github.com/jeremmfr/go-netconf v0.4.2
 	github.com/jeremmfr/go-utils v0.4.1
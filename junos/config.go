@@ -1,8 +1,15 @@
 package junos
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
+	"net"
+	"os"
+	"path"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
@@ -11,40 +18,133 @@ import (
 type configProvider struct {
 	junosFakeUpdateAlso      bool
 	junosFakeDeleteAlso      bool
+	junosSSHHostKeyTOFU      bool
+	junosCommitDeferred      bool
+	junosConfigCache         bool
+	junosPlanCommitCheck     bool
+	junosShowCompare         bool
+	junosShowCompareFile     bool
+	junosCommitSynchronize   bool
+	junosCommitForceSync     bool
 	junosPort                int
 	junosCmdSleepShort       int
 	junosCmdSleepLock        int
+	junosCmdLockMaxWait      int
+	junosCmdRPCTimeout       int
+	junosRetryAttempts       int
+	junosRetryBackoff        int
 	junosSSHSleepClosed      int
+	junosSessionPoolSize     int
+	junosCommitConfirmed     int
+	junosConfirmedCheck      int
 	junosIP                  string
 	junosUserName            string
 	junosPassword            string
 	junosSSHKeyPEM           string
 	junosSSHKeyFile          string
+	junosSSHCertPEM          string
+	junosSSHCertFile         string
 	junosKeyPass             string
+	junosTransport           string
+	junosTLSCertPEM          string
+	junosTLSCertFile         string
+	junosTLSKeyPEM           string
+	junosTLSKeyFile          string
+	junosTLSCABundlePEM      string
+	junosTLSCABundleFile     string
+	junosTLSServerName       string
 	junosGroupIntDel         string
+	junosConfigGroup         string
+	junosLogicalSystem       string
+	junosTenant              string
+	junosConfigMode          string
 	junosFilePermission      string
 	junosDebugNetconfLogPath string
 	junosFakeCreateSetFile   string
+	junosFakeSetFileFormat   string
+	junosOfflineConfigFile   string
+	junosOfflineModel        string
+	junosSSHKnownHostsFile   string
+	junosCommentTemplate     string
 	junosSSHCiphers          []string
+	junosSSHHostKeyFinger    []string
+	junosSSHJumpHosts        []configSSHJumpHost
+	junosCommitCommentVars   map[string]string
+}
+
+// configSSHJumpHost : information to connect on a jump host.
+type configSSHJumpHost struct {
+	port           int
+	host           string
+	username       string
+	password       string
+	sshKeyPEM      string
+	sshKeyFile     string
+	keyPass        string
+	knownHostsFile string
+	sshCertPEM     string
+	sshCertFile    string
+	hostKeyFinger  []string
 }
 
 // prepareSession : prepare information to connect to Junos Device and more.
-func (c *configProvider) prepareSession() (*Session, diag.Diagnostics) {
+func (c *configProvider) prepareSession(ctx context.Context) (*Session, diag.Diagnostics) {
+	shared := &sessionShared{
+		junosIP:                c.junosIP,
+		junosPort:              c.junosPort,
+		junosUserName:          c.junosUserName,
+		junosPassword:          c.junosPassword,
+		junosSSHKeyPEM:         c.junosSSHKeyPEM,
+		junosSSHCertPEM:        c.junosSSHCertPEM,
+		junosKeyPass:           c.junosKeyPass,
+		junosTransport:         c.junosTransport,
+		junosGroupIntDel:       c.junosGroupIntDel,
+		junosConfigMode:        c.junosConfigMode,
+		junosSleepLock:         c.junosCmdSleepLock,
+		junosLockMaxWait:       c.junosCmdLockMaxWait,
+		junosSleepShort:        c.junosCmdSleepShort,
+		junosSleepSSHClosed:    c.junosSSHSleepClosed,
+		junosSessionPoolSize:   c.junosSessionPoolSize,
+		junosCommitConfirmed:   c.junosCommitConfirmed,
+		junosConfirmedCheck:    c.junosConfirmedCheck,
+		junosPlanCommitCheck:   c.junosPlanCommitCheck,
+		junosShowCompare:       c.junosShowCompare,
+		junosCommitSynchronize: c.junosCommitSynchronize,
+		junosCommitForceSync:   c.junosCommitForceSync,
+		redundancy:             &redundancyCheck{},
+		junosRPCTimeout:        c.junosCmdRPCTimeout,
+		junosRetryAttempts:     c.junosRetryAttempts,
+		junosRetryBackoff:      c.junosRetryBackoff,
+		junosSSHCiphers:        c.junosSSHCiphers,
+		junosSSHHostKeyTOFU:    c.junosSSHHostKeyTOFU,
+		junosFakeUpdateAlso:    c.junosFakeUpdateAlso,
+		junosFakeDeleteAlso:    c.junosFakeDeleteAlso,
+		junosFakeSetFileFormat: c.junosFakeSetFileFormat,
+	}
 	sess := &Session{
-		junosIP:             c.junosIP,
-		junosPort:           c.junosPort,
-		junosUserName:       c.junosUserName,
-		junosPassword:       c.junosPassword,
-		junosSSHKeyPEM:      c.junosSSHKeyPEM,
-		junosKeyPass:        c.junosKeyPass,
-		junosGroupIntDel:    c.junosGroupIntDel,
-		junosSleepLock:      c.junosCmdSleepLock,
-		junosSleepShort:     c.junosCmdSleepShort,
-		junosSleepSSHClosed: c.junosSSHSleepClosed,
-		junosSSHCiphers:     c.junosSSHCiphers,
-		junosFakeUpdateAlso: c.junosFakeUpdateAlso,
-		junosFakeDeleteAlso: c.junosFakeDeleteAlso,
+		sessionShared: shared,
+		prefix: configPrefix{
+			group:         c.junosConfigGroup,
+			logicalSystem: c.junosLogicalSystem,
+			tenant:        c.junosTenant,
+		},
+	}
+	// junosSSHHostKeyFinger
+	for _, v := range c.junosSSHHostKeyFinger {
+		if !strings.HasPrefix(v, "SHA256:") {
+			return sess, diag.FromErr(fmt.Errorf("ssh host key fingerprint '%s' isn't a SHA256 fingerprint "+
+				"(need to start with 'SHA256:')", v))
+		}
+	}
+	sess.junosSSHHostKeyFinger = c.junosSSHHostKeyFinger
+
+	// junosCommentTemplate
+	if err := validateCommitCommentTemplate(c.junosCommentTemplate, c.junosCommitCommentVars); err != nil {
+		return sess, diag.FromErr(err)
 	}
+	sess.junosCommentTemplate = c.junosCommentTemplate
+	sess.junosCommitCommentVars = c.junosCommitCommentVars
+
 	// junosSSHKeyFile
 	sshKeyFile := c.junosSSHKeyFile
 	if err := replaceTildeToHomeDir(&sshKeyFile); err != nil {
@@ -52,6 +152,61 @@ func (c *configProvider) prepareSession() (*Session, diag.Diagnostics) {
 	}
 	sess.junosSSHKeyFile = sshKeyFile
 
+	// junosSSHCertFile
+	sshCertFile := c.junosSSHCertFile
+	if err := replaceTildeToHomeDir(&sshCertFile); err != nil {
+		return sess, diag.FromErr(err)
+	}
+	sess.junosSSHCertFile = sshCertFile
+
+	// junosSSHKnownHostsFile
+	sshKnownHostsFile := c.junosSSHKnownHostsFile
+	if err := replaceTildeToHomeDir(&sshKnownHostsFile); err != nil {
+		return sess, diag.FromErr(err)
+	}
+	sess.junosSSHKnownHostsFile = sshKnownHostsFile
+
+	// junosSSHJumpHosts
+	for _, jumpHost := range c.junosSSHJumpHosts {
+		netconfJump := netconfJumpHost{
+			Address: net.JoinHostPort(jumpHost.host, strconv.Itoa(jumpHost.port)),
+			Auth: netconfAuthMethod{
+				Username:            jumpHost.username,
+				Password:            jumpHost.password,
+				PrivateKeyPEM:       jumpHost.sshKeyPEM,
+				CertificatePEM:      jumpHost.sshCertPEM,
+				Passphrase:          jumpHost.keyPass,
+				Ciphers:             c.junosSSHCiphers,
+				HostKeyFingerprints: jumpHost.hostKeyFinger,
+			},
+		}
+		if netconfJump.Auth.Username == "" {
+			netconfJump.Auth.Username = c.junosUserName
+		}
+		for _, v := range jumpHost.hostKeyFinger {
+			if !strings.HasPrefix(v, "SHA256:") {
+				return sess, diag.FromErr(fmt.Errorf("ssh host key fingerprint '%s' of jump host %s isn't "+
+					"a SHA256 fingerprint (need to start with 'SHA256:')", v, jumpHost.host))
+			}
+		}
+		sshKeyFile := jumpHost.sshKeyFile
+		if err := replaceTildeToHomeDir(&sshKeyFile); err != nil {
+			return sess, diag.FromErr(err)
+		}
+		netconfJump.Auth.PrivateKeyFile = sshKeyFile
+		sshCertFile := jumpHost.sshCertFile
+		if err := replaceTildeToHomeDir(&sshCertFile); err != nil {
+			return sess, diag.FromErr(err)
+		}
+		netconfJump.Auth.CertificateFile = sshCertFile
+		knownHostsFile := jumpHost.knownHostsFile
+		if err := replaceTildeToHomeDir(&knownHostsFile); err != nil {
+			return sess, diag.FromErr(err)
+		}
+		netconfJump.Auth.KnownHostsFile = knownHostsFile
+		sess.junosSSHJumpHosts = append(sess.junosSSHJumpHosts, netconfJump)
+	}
+
 	// junosFilePermission
 	filePermission, err := strconv.ParseInt(c.junosFilePermission, 8, 64)
 	if err != nil {
@@ -59,6 +214,18 @@ func (c *configProvider) prepareSession() (*Session, diag.Diagnostics) {
 			c.junosFilePermission, err))
 	}
 	sess.junosFilePermission = filePermission
+	for i := range sess.junosSSHJumpHosts {
+		sess.junosSSHJumpHosts[i].Auth.FilePermission = filePermission
+	}
+
+	// junosTLSConfig
+	if c.junosTransport == transportTLS {
+		tlsConfig, err := c.genTLSConfig()
+		if err != nil {
+			return sess, diag.FromErr(err)
+		}
+		sess.junosTLSConfig = tlsConfig
+	}
 
 	// junosLogFile
 	junosLogFile := c.junosDebugNetconfLogPath
@@ -67,12 +234,92 @@ func (c *configProvider) prepareSession() (*Session, diag.Diagnostics) {
 	}
 	sess.junosLogFile = junosLogFile
 
+	// junosCompareFile
+	if c.junosShowCompareFile && junosLogFile != "" {
+		sess.junosCompareFile = strings.TrimSuffix(junosLogFile, path.Ext(junosLogFile)) +
+			"_compare_" + time.Now().Format("20060102-150405") + ".diff"
+	}
+
 	// junosFakeCreateSetFile
 	junosFakeCreateSetFile := c.junosFakeCreateSetFile
 	if err := replaceTildeToHomeDir(&junosFakeCreateSetFile); err != nil {
 		return sess, diag.FromErr(err)
 	}
 	sess.junosFakeCreateSetFile = junosFakeCreateSetFile
+	if c.junosFakeSetFileFormat == setFileFormatText || c.junosFakeSetFileFormat == setFileFormatXML {
+		sess.fakeTree = &configTree{}
+	}
+
+	// junosOfflineConfigFile
+	if c.junosOfflineConfigFile != "" {
+		offlineConfigFile := c.junosOfflineConfigFile
+		if err := replaceTildeToHomeDir(&offlineConfigFile); err != nil {
+			return sess, diag.FromErr(err)
+		}
+		offline, err := newOfflineConfig(offlineConfigFile, c.junosOfflineModel, sess.junosFilePermission)
+		if err != nil {
+			return sess, diag.FromErr(err)
+		}
+		sess.offline = offline
+		sess.dialTransport = offline.dial
+	}
+
+	// junosSessionPoolSize
+	if c.junosSessionPoolSize > 0 {
+		sess.pool = newSessionPool(c.junosSessionPoolSize)
+	}
+
+	// junosConfigCache
+	if c.junosConfigCache {
+		sess.cache = &configCache{}
+	}
+
+	// junosCommitDeferred
+	if c.junosCommitDeferred {
+		sess.deferred = &deferredCommit{}
+	}
+	registerSession(ctx, sess)
 
 	return sess, nil
 }
+
+// genTLSConfig : read certificates in PEM arguments or files to generate TLS configuration.
+func (c *configProvider) genTLSConfig() (*tls.Config, error) {
+	certPEM, err := readPEMOrFile(c.junosTLSCertPEM, c.junosTLSCertFile)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := readPEMOrFile(c.junosTLSKeyPEM, c.junosTLSKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	caPEM, err := readPEMOrFile(c.junosTLSCABundlePEM, c.junosTLSCABundleFile)
+	if err != nil {
+		return nil, err
+	}
+	serverName := c.junosTLSServerName
+	if serverName == "" {
+		serverName = c.junosIP
+	}
+
+	return genTLSClientConfig(certPEM, keyPEM, caPEM, serverName)
+}
+
+// readPEMOrFile : return PEM content if set, otherwise read file (with tilde expanded) if set.
+func readPEMOrFile(pem, file string) ([]byte, error) {
+	if pem != "" {
+		return []byte(pem), nil
+	}
+	if file == "" {
+		return nil, nil
+	}
+	if err := replaceTildeToHomeDir(&file); err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s : %w", file, err)
+	}
+
+	return content, nil
+}
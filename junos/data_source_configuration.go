@@ -0,0 +1,142 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	configurationFormatSet  = "set"
+	configurationFormatText = "text"
+	configurationFormatXML  = "xml"
+	configurationFormatJSON = "json"
+)
+
+func dataSourceConfiguration() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceConfigurationRead,
+		Schema: map[string]*schema.Schema{
+			"hierarchy": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"format": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  configurationFormatSet,
+				ValidateFunc: validation.StringInSlice([]string{
+					configurationFormatSet,
+					configurationFormatText,
+					configurationFormatXML,
+					configurationFormatJSON,
+				}, false),
+			},
+			"raw": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"lines": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"lines_map": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	hierarchy := strings.Join(strings.Fields(d.Get("hierarchy").(string)), " ")
+	mutex.Lock()
+	raw, err := readConfiguration(hierarchy, d.Get("format").(string), m, jnprSess)
+	if err != nil {
+		mutex.Unlock()
+
+		return diag.FromErr(err)
+	}
+	configLines, err := readConfigLines(hierarchy, m, jnprSess)
+	mutex.Unlock()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(configLinesID(hierarchy) + idSeparator + d.Get("format").(string))
+	if tfErr := d.Set("raw", raw); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("lines", configLines); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("lines_map", configLinesMap(configLines)); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return nil
+}
+
+// readConfiguration returns the configuration under hierarchy in format
+// (`set` lines are relative to hierarchy).
+func readConfiguration(hierarchy, format string, m interface{}, jnprSess *NetconfObject) (string, error) {
+	sess := m.(*Session)
+	cmd := strings.TrimSpace("show configuration " + hierarchy)
+	switch format {
+	case configurationFormatSet, configurationFormatText:
+		if format == configurationFormatSet {
+			cmd += " | display set relative"
+		}
+		showConfig, err := sess.command(cmd, jnprSess)
+		if err != nil {
+			return "", err
+		}
+		if showConfig == emptyWord {
+			return "", nil
+		}
+
+		return strings.TrimLeft(showConfig, "\n"), nil
+	case configurationFormatXML, configurationFormatJSON:
+		showConfig, err := sess.commandXML(fmt.Sprintf(rpcCommandFormat, format, cmd), jnprSess)
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimSpace(showConfig), nil
+	default:
+		return "", fmt.Errorf("unknown format '%s' for configuration", format)
+	}
+}
+
+// configLinesMap returns a flat map of `set` lines with the statement (without the last word) in key
+// and the last word (unquoted) in value.
+// The values of a statement configured multiple times are separated by a new line.
+func configLinesMap(configLines []string) map[string]string {
+	result := make(map[string]string)
+	for _, line := range configLines {
+		tokens := splitConfigSetTokens(line)
+		if len(tokens) < 2 || tokens[0] != "set" {
+			continue
+		}
+		key := strings.Join(tokens[1:len(tokens)-1], " ")
+		value := strings.Join(splitConfigSetWords(tokens[len(tokens)-1]), " ")
+		if v, ok := result[key]; ok {
+			result[key] = v + "\n" + value
+		} else {
+			result[key] = value
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,42 @@
+package junos
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestFakeDeviceDataSourceConfiguration(t *testing.T) {
+	sess, device := newFakeDeviceSession(t, "vsrx")
+	device.LoadCommitted(
+		"set protocols bgp group CORE type internal",
+		"set protocols bgp group CORE description \"core routers\"",
+		"set protocols bgp group CORE neighbor 192.0.2.1",
+		"set protocols bgp group CORE neighbor 192.0.2.2",
+		"set protocols bgp group EDGE type external",
+	)
+	ds := dataSourceConfiguration()
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"hierarchy": "protocols bgp group CORE",
+	})
+	if diags := ds.ReadContext(context.Background(), d, sess); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "protocols bgp group CORE"+idSeparator+"set" {
+		t.Errorf("unexpected id %q", d.Id())
+	}
+	if raw := d.Get("raw").(string); !strings.HasPrefix(raw, "set type internal\n") {
+		t.Errorf("unexpected raw configuration %q", raw)
+	}
+	if lines := d.Get("lines").([]interface{}); len(lines) != 4 {
+		t.Errorf("unexpected lines %q", lines)
+	}
+	linesMap := d.Get("lines_map").(map[string]interface{})
+	if linesMap["type"] != "internal" ||
+		linesMap["description"] != "core routers" ||
+		linesMap["neighbor"] != "192.0.2.1\n192.0.2.2" {
+		t.Errorf("unexpected lines_map %q", linesMap)
+	}
+}
@@ -0,0 +1,66 @@
+package junos_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceConfiguration_basic(t *testing.T) {
+	if os.Getenv("TESTACC_SWITCH") == "" {
+		resource.Test(t, resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccDataSourceConfigurationConfigCreate(),
+				},
+				{
+					Config: testAccDataSourceConfigurationConfigData(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("data.junos_configuration.testacc_set",
+							"lines.#", "2"),
+						resource.TestCheckResourceAttr("data.junos_configuration.testacc_set",
+							"lines_map.description", "testacc configuration"),
+						resource.TestCheckResourceAttr("data.junos_configuration.testacc_set",
+							"lines_map.instance-type", "virtual-router"),
+						resource.TestCheckResourceAttrSet("data.junos_configuration.testacc_text",
+							"raw"),
+						resource.TestCheckResourceAttrSet("data.junos_configuration.testacc_json",
+							"raw"),
+					),
+				},
+			},
+		})
+	}
+}
+
+func testAccDataSourceConfigurationConfigCreate() string {
+	return `
+resource junos_routing_instance "testacc_configuration" {
+  name        = "testacc_configuration"
+  description = "testacc configuration"
+}
+`
+}
+
+func testAccDataSourceConfigurationConfigData() string {
+	return `
+resource junos_routing_instance "testacc_configuration" {
+  name        = "testacc_configuration"
+  description = "testacc configuration"
+}
+data junos_configuration "testacc_set" {
+  hierarchy = "routing-instances ${junos_routing_instance.testacc_configuration.name}"
+}
+data junos_configuration "testacc_text" {
+  hierarchy = "routing-instances ${junos_routing_instance.testacc_configuration.name}"
+  format    = "text"
+}
+data junos_configuration "testacc_json" {
+  hierarchy = "routing-instances ${junos_routing_instance.testacc_configuration.name}"
+  format    = "json"
+}
+`
+}
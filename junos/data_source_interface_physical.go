@@ -339,7 +339,7 @@ func dataSourceInterfacePhysicalRead(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(fmt.Errorf("no arguments provided, 'config_interface' and 'match' empty"))
 	}
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -388,7 +388,7 @@ func searchInterfacePhysicalID(configInterface string, match string,
 		if item == "" {
 			continue
 		}
-		itemTrim := strings.TrimPrefix(item, "set interfaces ")
+		itemTrim := strings.TrimPrefix(item, sess.prefix.setLine("interfaces "))
 		matched, err := regexp.MatchString(match, itemTrim)
 		if err != nil {
 			return "", fmt.Errorf("failed to regexp with %s : %w", match, err)
@@ -73,7 +73,7 @@ func dataSourceInterfacesPhysicalPresent() *schema.Resource {
 func dataSourceInterfacesPhysicalPresentRead(ctx context.Context,
 	d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
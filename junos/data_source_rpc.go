@@ -0,0 +1,194 @@
+package junos
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// rpcDenyPrefixes are the prefixes of rpc names which change the state of device.
+var rpcDenyPrefixes = []string{ // nolint: gochecknoglobals
+	"clear-",
+	"commit-",
+	"load-",
+	"request-",
+	"restart-",
+	"rollback-",
+	"set-",
+	"start-",
+	"stop-",
+}
+
+// rpcDenyNames are the rpc names which change the state of device
+// (or the sessions and configuration of provider) without a prefix of rpcDenyPrefixes.
+var rpcDenyNames = map[string]bool{ // nolint: gochecknoglobals
+	"close-configuration":  true,
+	"close-session":        true,
+	"command":              true,
+	"copy-config":          true,
+	"delete-config":        true,
+	"discard-changes":      true,
+	"edit-config":          true,
+	"file-archive":         true,
+	"file-copy":            true,
+	"file-delete":          true,
+	"file-put":             true,
+	"file-rename":          true,
+	"kill-session":         true,
+	"lock":                 true,
+	"lock-configuration":   true,
+	"open-configuration":   true,
+	"unlock":               true,
+	"unlock-configuration": true,
+}
+
+var rpcNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]*$`) // nolint: gochecknoglobals
+
+func dataSourceRPC() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRPCRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateRPCName,
+			},
+			"arguments": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"xpaths": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"xml": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"values": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceRPCRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	rpc, err := rpcFromArguments(d.Get("name").(string), d.Get("arguments").(map[string]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	replyData, err := sess.commandXML(rpc, jnprSess)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	reply, err := parseXMLTree(replyData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	replyJSON, err := reply.json()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	values := make(map[string]string)
+	for k, v := range d.Get("xpaths").(map[string]interface{}) {
+		selected, err := reply.xpath(v.(string))
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failed to select value of %s : %w", k, err))
+		}
+		values[k] = strings.Join(selected, "\n")
+	}
+	d.SetId(d.Get("name").(string))
+	if tfErr := d.Set("xml", strings.TrimSpace(replyData)); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("json", replyJSON); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("values", values); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return nil
+}
+
+func validateRPCName(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+
+		return warnings, errors
+	}
+	if !rpcNameRegexp.MatchString(v) {
+		errors = append(errors, fmt.Errorf("expected %s to be a valid rpc name, got: %s", k, v))
+
+		return warnings, errors
+	}
+	if err := checkRPCAllowed(v); err != nil {
+		errors = append(errors, fmt.Errorf("%s: %w", k, err))
+	}
+
+	return warnings, errors
+}
+
+// checkRPCAllowed returns an error if the rpc changes the state of device.
+func checkRPCAllowed(name string) error {
+	if rpcDenyNames[name] {
+		return fmt.Errorf("rpc %q isn't allowed (change the state of device)", name)
+	}
+	for _, prefix := range rpcDenyPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return fmt.Errorf("rpc %q isn't allowed (rpc with prefix %q change the state of device)", name, prefix)
+		}
+	}
+
+	return nil
+}
+
+// rpcFromArguments returns the rpc with arguments in elements (an empty value is a tag without content).
+func rpcFromArguments(name string, arguments map[string]interface{}) (string, error) {
+	if err := checkRPCAllowed(name); err != nil {
+		return "", err
+	}
+	keys := make([]string, 0, len(arguments))
+	for k := range arguments {
+		if !rpcNameRegexp.MatchString(k) {
+			return "", fmt.Errorf("%q is not a valid argument name for rpc %q", k, name)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var rpc strings.Builder
+	rpc.WriteString("<" + name + ">")
+	for _, k := range keys {
+		if v := arguments[k].(string); v == "" {
+			rpc.WriteString("<" + k + "/>")
+		} else {
+			rpc.WriteString("<" + k + ">")
+			_ = xml.EscapeText(&rpc, []byte(v))
+			rpc.WriteString("</" + k + ">")
+		}
+	}
+	rpc.WriteString("</" + name + ">")
+
+	return rpc.String(), nil
+}
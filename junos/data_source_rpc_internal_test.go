@@ -0,0 +1,43 @@
+package junos
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestFakeDeviceDataSourceRPC(t *testing.T) {
+	sess, device := newFakeDeviceSession(t, "vsrx")
+	device.SetInterfaces("ge-0/0/0", "ge-0/0/1")
+	device.LoadCommitted("set interfaces ge-0/0/1 disable")
+	ds := dataSourceRPC()
+	d := schema.TestResourceDataRaw(t, ds.Schema, map[string]interface{}{
+		"name":      "get-interface-information",
+		"arguments": map[string]interface{}{"terse": ""},
+		"xpaths": map[string]interface{}{
+			"names":    "//physical-interface/name",
+			"disabled": "//physical-interface[admin-status='down']/name",
+		},
+	})
+	if diags := ds.ReadContext(context.Background(), d, sess); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() != "get-interface-information" {
+		t.Errorf("unexpected id %q", d.Id())
+	}
+	values := d.Get("values").(map[string]interface{})
+	if values["names"] != "ge-0/0/0\nge-0/0/1" || values["disabled"] != "ge-0/0/1" {
+		t.Errorf("unexpected values %q", values)
+	}
+	if v := d.Get("json").(string); !strings.HasPrefix(v, `{"interface-information":{"physical-interface":[`) {
+		t.Errorf("unexpected json %q", v)
+	}
+	if diags := ds.Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "request-system-reboot",
+	})); !diags.HasError() {
+		t.Errorf("rpc request-system-reboot accepted")
+	}
+}
@@ -0,0 +1,57 @@
+package junos_test
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceRPC_basic(t *testing.T) {
+	if os.Getenv("TESTACC_SWITCH") == "" {
+		resource.Test(t, resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccDataSourceRPCConfig(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("data.junos_rpc.testacc_rpc",
+							"id", "get-software-information"),
+						resource.TestCheckResourceAttrSet("data.junos_rpc.testacc_rpc",
+							"values.version"),
+						resource.TestMatchResourceAttr("data.junos_rpc.testacc_rpc",
+							"json", regexp.MustCompile(`^\{"software-information":`)),
+					),
+				},
+				{
+					Config:      testAccDataSourceRPCConfigDenied(),
+					ExpectError: regexp.MustCompile(`isn't allowed`),
+				},
+			},
+		})
+	}
+}
+
+func testAccDataSourceRPCConfig() string {
+	return `
+data junos_rpc "testacc_rpc" {
+  name = "get-software-information"
+  arguments = {
+    brief = ""
+  }
+  xpaths = {
+    version = "//junos-version"
+  }
+}
+`
+}
+
+func testAccDataSourceRPCConfigDenied() string {
+	return `
+data junos_rpc "testacc_rpc" {
+  name = "request-system-reboot"
+}
+`
+}
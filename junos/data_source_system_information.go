@@ -38,7 +38,7 @@ func dataSourceSystemInformation() *schema.Resource {
 
 func dataSourceSystemInformationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	j, err := sess.startNewSession()
+	j, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
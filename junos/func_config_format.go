@@ -0,0 +1,184 @@
+package junos
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	setFileFormatSet  = "set"
+	setFileFormatText = "text"
+	setFileFormatXML  = "xml"
+
+	rpcLoadConfigText = "<load-configuration action=\"merge\" format=\"text\"><configuration-text>\n%s" +
+		"</configuration-text></load-configuration>\n"
+)
+
+// configTreeListContainers are the statements which contain directly the entries of a list
+// (like `interfaces ge-0/0/0`), a block is opened for each entry.
+var configTreeListContainers = map[string]bool{ // nolint: gochecknoglobals
+	"bridge-domains":    true,
+	"groups":            true,
+	"interfaces":        true,
+	"logical-systems":   true,
+	"routing-instances": true,
+	"vlans":             true,
+}
+
+// configTreeRootEntries are the list containers whose entries have the statements of top of hierarchy.
+var configTreeRootEntries = map[string]bool{ // nolint: gochecknoglobals
+	"groups":            true,
+	"logical-systems":   true,
+	"routing-instances": true,
+}
+
+// configTree is a configuration built from `set` and `delete` lines, without schema:
+// each word of lines is a node of tree.
+type configTree struct {
+	mutex sync.Mutex
+	root  configTreeNode
+}
+
+// configTreeNode is a word of configuration with its next words.
+// A node is deleted with a `delete` line and replaced with `set` lines after its deletion.
+type configTreeNode struct {
+	deleted  bool
+	replaced bool
+	word     string
+	children []*configTreeNode
+}
+
+// child returns the child node with word, added if not found.
+func (n *configTreeNode) child(word string) *configTreeNode {
+	for _, c := range n.children {
+		if c.word == word {
+			return c
+		}
+	}
+	c := &configTreeNode{word: word}
+	n.children = append(n.children, c)
+
+	return c
+}
+
+// apply applies a `set` or `delete` line on tree, other lines are ignored.
+func (t *configTree) apply(line string) {
+	tokens := splitConfigSetTokens(line)
+	if len(tokens) < 2 {
+		return
+	}
+	node := &t.root
+	switch tokens[0] {
+	case "set":
+		for _, token := range tokens[1:] {
+			node = node.child(token)
+			if node.deleted {
+				node.deleted = false
+				node.replaced = true
+			}
+		}
+	case "delete":
+		for _, token := range tokens[1:] {
+			node = node.child(token)
+		}
+		node.children = nil
+		node.deleted = true
+		node.replaced = false
+	}
+}
+
+// text returns the configuration in hierarchical text format.
+// Without schema, blocks are opened only for the containers of top of hierarchy and the entries
+// of list containers (like `interfaces ge-0/0/0`), the statements under them are written
+// on a single line (like `unit 0 family inet address 192.0.2.1/24;`).
+// Deleted statements are written with the `delete:` tag (before the new statements if replaced).
+func (t *configTree) text() string {
+	var text strings.Builder
+	for _, node := range t.root.children {
+		writeConfigTreeRoot(&text, node, 0)
+	}
+
+	return text.String()
+}
+
+// xml returns the configuration in text format in a `<load-configuration>` rpc (Junos XML API).
+func (t *configTree) xml() string {
+	var text strings.Builder
+	_ = xml.EscapeText(&text, []byte(t.text()))
+
+	return fmt.Sprintf(rpcLoadConfigText, text.String())
+}
+
+// writeConfigTreeRoot writes a statement of top of hierarchy, in a block if it's a container
+// (with statements under its children) or on a single line (like `instance-type virtual-router;`).
+func writeConfigTreeRoot(text *strings.Builder, node *configTreeNode, depth int) {
+	container := false
+	for _, child := range node.children {
+		if len(child.children) > 0 {
+			container = true
+
+			break
+		}
+	}
+	if !container {
+		writeConfigTreeFlat(text, node, depth, nil)
+
+		return
+	}
+	writeConfigTreeBlock(text, node, depth, func() {
+		for _, child := range node.children {
+			switch {
+			case !configTreeListContainers[node.word] || len(child.children) == 0:
+				writeConfigTreeFlat(text, child, depth+1, nil)
+			case configTreeRootEntries[node.word]:
+				writeConfigTreeBlock(text, child, depth+1, func() {
+					for _, entryChild := range child.children {
+						writeConfigTreeRoot(text, entryChild, depth+2)
+					}
+				})
+			default:
+				writeConfigTreeBlock(text, child, depth+1, func() {
+					for _, entryChild := range child.children {
+						writeConfigTreeFlat(text, entryChild, depth+2, nil)
+					}
+				})
+			}
+		}
+	})
+}
+
+// writeConfigTreeBlock writes a statement with its content in a block.
+func writeConfigTreeBlock(text *strings.Builder, node *configTreeNode, depth int, content func()) {
+	indent := strings.Repeat("    ", depth)
+	if node.deleted || node.replaced {
+		text.WriteString(indent + "delete: " + node.word + ";\n")
+	}
+	if len(node.children) == 0 {
+		return
+	}
+	text.WriteString(indent + node.word + " {\n")
+	content()
+	text.WriteString(indent + "}\n")
+}
+
+// writeConfigTreeFlat writes the statements of node on a single line for each leaf.
+func writeConfigTreeFlat(text *strings.Builder, node *configTreeNode, depth int, prefix []string) {
+	indent := strings.Repeat("    ", depth)
+	statement := append(append(make([]string, 0, len(prefix)+1), prefix...), node.word)
+	if node.deleted || node.replaced {
+		text.WriteString(indent + "delete: " + strings.Join(statement, " ") + ";\n")
+	}
+	if node.deleted {
+		return
+	}
+	if len(node.children) == 0 {
+		text.WriteString(indent + strings.Join(statement, " ") + ";\n")
+
+		return
+	}
+	for _, child := range node.children {
+		writeConfigTreeFlat(text, child, depth, statement)
+	}
+}
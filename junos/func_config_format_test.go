@@ -0,0 +1,92 @@
+package junos
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestConfigTreeText(t *testing.T) {
+	tree := &configTree{}
+	for _, line := range []string{
+		"set system host-name test",
+		"set system services ssh",
+		"set interfaces ge-0/0/0 description \"uplink to core\"",
+		"set interfaces ge-0/0/0 unit 0 family inet address 192.0.2.1/24",
+		"set interfaces ge-0/0/0 unit 0 family inet address 198.51.100.1/24",
+		"delete interfaces ge-0/0/1",
+		"set routing-instances test instance-type virtual-router",
+		"delete routing-instances test routing-options static",
+		"set routing-instances test routing-options static route 192.0.2.0/24 discard",
+		"set apply-groups common",
+	} {
+		tree.apply(line)
+	}
+	expected := `system {
+    host-name test;
+    services ssh;
+}
+interfaces {
+    ge-0/0/0 {
+        description "uplink to core";
+        unit 0 family inet address 192.0.2.1/24;
+        unit 0 family inet address 198.51.100.1/24;
+    }
+    delete: ge-0/0/1;
+}
+routing-instances {
+    test {
+        instance-type virtual-router;
+        routing-options {
+            delete: static;
+            static route 192.0.2.0/24 discard;
+        }
+    }
+}
+apply-groups common;
+`
+	if text := tree.text(); text != expected {
+		t.Errorf("unexpected configuration in text format:\n%s\nexpected:\n%s", text, expected)
+	}
+	if xmlConfig := tree.xml(); !strings.HasPrefix(xmlConfig, "<load-configuration action=\"merge\" format=\"text\">") ||
+		!strings.Contains(xmlConfig, "description &#34;uplink to core&#34;;") {
+		t.Errorf("unexpected configuration in xml format:\n%s", xmlConfig)
+	}
+}
+
+func TestFakeCreateSetFileFormat(t *testing.T) {
+	setFile := filepath.Join(t.TempDir(), "config.conf")
+	c := configProvider{
+		junosFilePermission:    "644",
+		junosFakeCreateSetFile: setFile,
+		junosFakeSetFileFormat: setFileFormatText,
+	}
+	sess, diags := c.prepareSession(context.Background())
+	if diags.HasError() {
+		t.Fatalf("prepareSession: %v", diags)
+	}
+	res := resourceRoutingInstance()
+	for _, name := range []string{"first", "second"} {
+		d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+			"name": name,
+		})
+		if diags := res.CreateContext(context.Background(), d, sess); diags.HasError() {
+			t.Fatalf("create: %v", diags)
+		}
+	}
+	config, err := os.ReadFile(setFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "routing-instances {\n" +
+		"    first {\n        instance-type virtual-router;\n    }\n" +
+		"    second {\n        instance-type virtual-router;\n    }\n" +
+		"}\n"
+	if string(config) != expected {
+		t.Errorf("unexpected file:\n%s\nexpected:\n%s", config, expected)
+	}
+}
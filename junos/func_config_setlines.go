@@ -0,0 +1,99 @@
+package junos
+
+import (
+	"strings"
+)
+
+const (
+	showConfigurationWord  = "show configuration"
+	displaySetWord         = "display set"
+	displaySetRelativeWord = "display set relative"
+	configurationOutput    = "<configuration-output>"
+	configurationOutputEnd = "</configuration-output>"
+)
+
+// setLinesFilter is a `show configuration <path> | display set [relative]` command
+// decoded to filter a list of set lines.
+type setLinesFilter struct {
+	relative bool
+	path     string
+}
+
+// newSetLinesFilter decodes a `show configuration` command with `display set` output.
+// Return false if the command can't be served with a list of set lines.
+func newSetLinesFilter(cmd string) (setLinesFilter, bool) {
+	var filter setLinesFilter
+	if !strings.HasPrefix(cmd, showConfigurationWord) {
+		return filter, false
+	}
+	cmdSplit := strings.Split(strings.TrimPrefix(cmd, showConfigurationWord), "|")
+	if len(cmdSplit) != 2 {
+		return filter, false
+	}
+	switch strings.TrimSpace(cmdSplit[1]) {
+	case displaySetWord:
+	case displaySetRelativeWord:
+		filter.relative = true
+	default:
+		return filter, false
+	}
+	filter.path = strings.Join(strings.Fields(cmdSplit[0]), " ")
+
+	return filter, true
+}
+
+// filter returns lines under the path of filter, relative to path if needed.
+func (filter setLinesFilter) filter(lines []string) []string {
+	result := make([]string, 0)
+	for _, line := range lines {
+		lineSplit := strings.SplitN(line, " ", 2)
+		if len(lineSplit) != 2 {
+			continue
+		}
+		switch {
+		case filter.path == "":
+			result = append(result, line)
+		case lineSplit[1] == filter.path:
+			if filter.relative {
+				result = append(result, lineSplit[0])
+			} else {
+				result = append(result, line)
+			}
+		case strings.HasPrefix(lineSplit[1], filter.path+" "):
+			if filter.relative {
+				result = append(result, lineSplit[0]+" "+strings.TrimPrefix(lineSplit[1], filter.path+" "))
+			} else {
+				result = append(result, line)
+			}
+		}
+	}
+
+	return result
+}
+
+// output generates the same output as Junos device for the command with the filtered lines.
+func (filter setLinesFilter) output(lines []string) string {
+	result := filter.filter(lines)
+	if len(result) == 0 {
+		return emptyWord
+	}
+	replacer := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+	return "\n" + configurationOutput + "\n" + replacer.Replace(strings.Join(result, "\n")) + "\n" +
+		configurationOutputEnd + "\n"
+}
+
+// splitSetLines splits a text output of configuration in `set` format to a list of lines
+// without empty lines and comments.
+func splitSetLines(config string) []string {
+	lines := make([]string, 0)
+	for _, line := range strings.Split(config, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
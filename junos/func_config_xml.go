@@ -0,0 +1,342 @@
+package junos
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const rpcGetConfigurationXML = "<get-configuration database=\"%s\" format=\"xml\"><configuration>%s</configuration>" +
+	"</get-configuration>"
+
+// configXMLElement is an element of a hierarchy in configuration:
+// a container or an entry of a list identified by its name.
+type configXMLElement struct {
+	tag  string
+	name string
+}
+
+// xmlContainer returns a container element of hierarchy.
+func xmlContainer(tag string) configXMLElement {
+	return configXMLElement{tag: tag}
+}
+
+// xmlEntry returns an entry element of a list in hierarchy.
+func xmlEntry(tag, name string) configXMLElement {
+	return configXMLElement{tag: tag, name: name}
+}
+
+// configXMLNode is a generic element of configuration in XML to walk through the reply.
+type configXMLNode struct {
+	XMLName  xml.Name
+	Inactive string          `xml:"inactive,attr"`
+	Name     string          `xml:"name"`
+	Inner    []byte          `xml:",innerxml"`
+	Nodes    []configXMLNode `xml:",any"`
+}
+
+// configXMLFlag is a configuration statement without value (true if present).
+type configXMLFlag bool
+
+func (f *configXMLFlag) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*f = true
+
+	return d.Skip()
+}
+
+// configXMLInt is a configuration statement with an integer value, directly in element or
+// in a sub-element (like `<metric><metric-value>10</metric-value></metric>`).
+type configXMLInt int
+
+func (i *configXMLInt) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	value, err := configXMLText(d)
+	if err != nil {
+		return err
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("failed to convert value from '%s' to integer in <%s> : %w", value, start.Name.Local, err)
+	}
+	*i = configXMLInt(v)
+
+	return nil
+}
+
+// configXMLText reads the text of an element and its sub-elements.
+func configXMLText(d *xml.Decoder) (string, error) {
+	var text strings.Builder
+	for depth := 1; depth > 0; {
+		token, err := d.Token()
+		if err != nil {
+			return "", fmt.Errorf("failed to decode xml : %w", err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			text.Write(t)
+		}
+	}
+
+	return strings.TrimSpace(text.String()), nil
+}
+
+// configurationXML reads a hierarchy of configuration with a `<get-configuration>` rpc in XML
+// and decodes the last element of path in v (if not nil).
+// It reads the committed configuration (or its snapshot if config cache is enabled)
+// or the candidate configuration with deferred changes.
+// Return false if the hierarchy doesn't exist.
+func (sess *Session) configurationXML(path []configXMLElement, v interface{}, jnpr *NetconfObject) (bool, error) {
+	path = sess.prefix.path(path)
+	if sess.offline != nil {
+		if v != nil {
+			return false, errors.New("configuration in XML " + offlineNotAvailable + ", read the set lines")
+		}
+		lines, err := sess.configurationSetLines(path, jnpr)
+
+		return len(lines) > 0, err
+	}
+	filter := configXMLFilter(path)
+	var reply string
+	var err error
+	if sess.deferred != nil && sess.deferred.hasChanges() {
+		reply, err = sess.deferred.commandXML(jnpr.ctx, sess, fmt.Sprintf(rpcGetConfigurationXML, "candidate", filter))
+	} else if sess.cache != nil {
+		return sess.cache.configurationXML(sess, path, v, jnpr)
+	} else {
+		reply, err = sess.commandXML(fmt.Sprintf(rpcGetConfigurationXML, "committed", filter), jnpr)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return decodeConfigXML(reply, path, v)
+}
+
+// configXMLFilter generates the sub-tree filter of get-configuration for a hierarchy.
+func configXMLFilter(path []configXMLElement) string {
+	var filter strings.Builder
+	for _, element := range path {
+		filter.WriteString("<" + element.tag + ">")
+		if element.name != "" {
+			filter.WriteString("<name>")
+			_ = xml.EscapeText(&filter, []byte(element.name))
+			filter.WriteString("</name>")
+		}
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		filter.WriteString("</" + path[i].tag + ">")
+	}
+
+	return filter.String()
+}
+
+// decodeConfigXML finds the last element of path in the data of get-configuration reply
+// and decodes it in v (if not nil).
+// The inactive elements (deactivated statements) are read as not configured.
+func decodeConfigXML(data string, path []configXMLElement, v interface{}) (bool, error) {
+	var node configXMLNode
+	if err := xml.Unmarshal([]byte(data), &node); err != nil {
+		if strings.TrimSpace(data) == "" {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to xml unmarshal reply : %w", err)
+	}
+	if node.XMLName.Local != "configuration" {
+		return false, fmt.Errorf("unexpected element <%s> in get-configuration reply", node.XMLName.Local)
+	}
+	for _, element := range path {
+		found := false
+		for _, child := range node.Nodes {
+			if child.XMLName.Local == element.tag && (element.name == "" || child.Name == element.name) {
+				node = child
+				found = true
+
+				break
+			}
+		}
+		if !found || node.Inactive != "" {
+			return false, nil
+		}
+	}
+	if v == nil {
+		return true, nil
+	}
+	var element bytes.Buffer
+	element.WriteString("<" + node.XMLName.Local + ">")
+	element.Write(node.Inner)
+	element.WriteString("</" + node.XMLName.Local + ">")
+	activeElement, err := removeInactiveConfigXML(element.Bytes())
+	if err != nil {
+		return true, fmt.Errorf("failed to remove inactive elements of <%s> : %w", node.XMLName.Local, err)
+	}
+	if err := xml.Unmarshal(activeElement, v); err != nil {
+		return true, fmt.Errorf("failed to xml unmarshal <%s> : %w", node.XMLName.Local, err)
+	}
+
+	return true, nil
+}
+
+// removeInactiveConfigXML removes the elements with the `inactive` attribute (and their sub-elements)
+// in data. The attributes and namespaces of the other elements are also removed.
+func removeInactiveConfigXML(data []byte) ([]byte, error) {
+	var output bytes.Buffer
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	encoder := xml.NewEncoder(&output)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			inactive := false
+			for _, attr := range t.Attr {
+				if attr.Name.Local == "inactive" {
+					inactive = true
+				}
+			}
+			if inactive {
+				if err := decoder.Skip(); err != nil {
+					return nil, err
+				}
+
+				continue
+			}
+			token = xml.StartElement{Name: xml.Name{Local: t.Name.Local}}
+		case xml.EndElement:
+			token = xml.EndElement{Name: xml.Name{Local: t.Name.Local}}
+		case xml.CharData:
+		default:
+			continue
+		}
+		if err := encoder.EncodeToken(token); err != nil {
+			return nil, err
+		}
+	}
+	if err := encoder.Flush(); err != nil {
+		return nil, err
+	}
+
+	return output.Bytes(), nil
+}
+
+// configurationSetLines reads a hierarchy of configuration with `show configuration | display set relative`
+// for devices without XML configuration (offline mode) and returns its relative `set` lines
+// (without the `set` word), to be parsed like the other resources.
+// The statements in a `deactivate` line are read as not configured.
+func (sess *Session) configurationSetLines(path []configXMLElement, jnpr *NetconfObject) ([]string, error) {
+	words := make([]string, 0, len(path)*2)
+	for _, element := range path {
+		words = append(words, element.tag)
+		if element.name != "" {
+			if strings.Contains(element.name, " ") {
+				words = append(words, "\""+element.name+"\"")
+			} else {
+				words = append(words, element.name)
+			}
+		}
+	}
+	showConfig, err := sess.command("show configuration "+strings.Join(words, " ")+" | display set relative", jnpr)
+	if err != nil {
+		return nil, err
+	}
+	lines := make([]string, 0)
+	if showConfig == emptyWord {
+		return lines, nil
+	}
+	deactivated := make([][]string, 0)
+	for _, line := range strings.Split(showConfig, "\n") {
+		switch {
+		case line == "set" || strings.HasPrefix(line, "set "):
+			lines = append(lines, strings.TrimPrefix(strings.TrimPrefix(line, "set"), " "))
+		case line == "deactivate" || strings.HasPrefix(line, "deactivate "):
+			deactivated = append(deactivated, splitConfigSetWords(strings.TrimPrefix(line, "deactivate")))
+		}
+	}
+
+	return removeDeactivatedConfigSetLines(lines, deactivated), nil
+}
+
+// removeDeactivatedConfigSetLines removes the relative `set` lines under a hierarchy of deactivated words.
+func removeDeactivatedConfigSetLines(lines []string, deactivated [][]string) []string {
+	if len(deactivated) == 0 {
+		return lines
+	}
+	activeLines := make([]string, 0, len(lines))
+	for _, line := range lines {
+		words := splitConfigSetWords(line)
+		active := true
+		for _, deactivatedWords := range deactivated {
+			if len(deactivatedWords) > len(words) {
+				continue
+			}
+			if reflect.DeepEqual(words[:len(deactivatedWords)], deactivatedWords) {
+				active = false
+
+				break
+			}
+		}
+		if active {
+			activeLines = append(activeLines, line)
+		}
+	}
+
+	return activeLines
+}
+
+// splitConfigSetWords splits a `set` line in words, the words in double quotes are kept together
+// (without the quotes).
+func splitConfigSetWords(line string) []string {
+	words := splitConfigSetTokens(line)
+	for i, word := range words {
+		if len(word) >= 2 && strings.HasPrefix(word, "\"") && strings.HasSuffix(word, "\"") {
+			words[i] = strings.NewReplacer("\\\"", "\"", "\\\\", "\\").Replace(word[1 : len(word)-1])
+		}
+	}
+
+	return words
+}
+
+// splitConfigSetTokens splits a `set` line in tokens, the words in double quotes are kept together
+// (with the quotes).
+func splitConfigSetTokens(line string) []string {
+	tokens := make([]string, 0)
+	var token strings.Builder
+	inQuotes := false
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && inQuotes:
+			escaped = true
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == ' ' && !inQuotes:
+			if token.Len() > 0 {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+
+			continue
+		}
+		token.WriteRune(r)
+	}
+	if token.Len() > 0 {
+		tokens = append(tokens, token.String())
+	}
+
+	return tokens
+}
@@ -0,0 +1,193 @@
+package junos
+
+import (
+	"strings"
+	"testing"
+)
+
+const testConfigXMLStaticRoute = `<configuration xmlns:junos="http://xml.juniper.net/junos/*/junos"
+ junos:commit-seconds="1644000000" junos:commit-user="netconf">
+<routing-instances>
+<instance>
+<name>prod</name>
+<routing-options>
+<rib>
+<name>prod.inet6.0</name>
+<static>
+<route>
+<name>2001:db8::/64</name>
+<discard/>
+</route>
+</static>
+</rib>
+<static>
+<route>
+<name>192.0.2.0/24</name>
+<next-hop>198.51.100.1</next-hop>
+<next-hop>198.51.100.2</next-hop>
+<qualified-next-hop>
+<name>198.51.100.3</name>
+<interface>ge-0/0/3.0</interface>
+<preference>7</preference>
+<metric>5</metric>
+</qualified-next-hop>
+<as-path>
+<path>65000 65001</path>
+<aggregator>
+<as-number>65000</as-number>
+<address>192.0.2.1</address>
+</aggregator>
+</as-path>
+<community>65000:100</community>
+<community>no-export</community>
+<preference>
+<metric-value>12</metric-value>
+</preference>
+<metric>
+<metric-value>100</metric-value>
+</metric>
+<no-readvertise/>
+<active/>
+</route>
+</static>
+</routing-options>
+</instance>
+</routing-instances>
+</configuration>`
+
+func TestConfigXMLFilter(t *testing.T) {
+	filter := configXMLFilter(staticRouteXMLPath("192.0.2.0/24", "a&b"))
+	expected := "<routing-instances><instance><name>a&amp;b</name><routing-options><static>" +
+		"<route><name>192.0.2.0/24</name></route></static></routing-options></instance></routing-instances>"
+	if filter != expected {
+		t.Errorf("unexpected filter\n got: %s\nwant: %s", filter, expected)
+	}
+}
+
+func TestDecodeConfigXMLStaticRoute(t *testing.T) {
+	var config staticRouteXML
+	found, err := decodeConfigXML(testConfigXMLStaticRoute, staticRouteXMLPath("192.0.2.0/24", "prod"), &config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatal("static route not found")
+	}
+	if !config.Active || !config.NoReadvertise || config.Discard {
+		t.Errorf("unexpected flags %+v", config)
+	}
+	if config.Preference != 12 || config.Metric != 100 {
+		t.Errorf("unexpected preference %d or metric %d", config.Preference, config.Metric)
+	}
+	if len(config.NextHop) != 2 || config.NextHop[1] != "198.51.100.2" {
+		t.Errorf("unexpected next-hop %q", config.NextHop)
+	}
+	if len(config.QualifiedNextHop) != 1 || config.QualifiedNextHop[0].Interface != "ge-0/0/3.0" ||
+		config.QualifiedNextHop[0].Preference != 7 || config.QualifiedNextHop[0].Metric != 5 {
+		t.Errorf("unexpected qualified-next-hop %+v", config.QualifiedNextHop)
+	}
+	if config.AsPath.Path != "65000 65001" || config.AsPath.Aggregator.Address != "192.0.2.1" {
+		t.Errorf("unexpected as-path %+v", config.AsPath)
+	}
+	if len(config.Community) != 2 || config.Community[1] != "no-export" {
+		t.Errorf("unexpected community %q", config.Community)
+	}
+}
+
+func TestDecodeConfigXMLNotFound(t *testing.T) {
+	for _, path := range [][]configXMLElement{
+		staticRouteXMLPath("192.0.2.0/24", defaultWord),
+		staticRouteXMLPath("192.0.2.0/25", "prod"),
+		staticRouteXMLPath("2001:db8::/64", "other"),
+	} {
+		found, err := decodeConfigXML(testConfigXMLStaticRoute, path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if found {
+			t.Errorf("hierarchy %q found", configXMLFilter(path))
+		}
+	}
+	found, err := decodeConfigXML(testConfigXMLStaticRoute, staticRouteXMLPath("2001:db8::/64", "prod"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Error("static route in rib prod.inet6.0 not found")
+	}
+	found, err = decodeConfigXML("\n", staticRouteXMLPath("192.0.2.0/24", "prod"), nil)
+	if err != nil || found {
+		t.Errorf("empty reply: found %t, err %v", found, err)
+	}
+}
+
+const testConfigXMLStaticRouteInactive = `<configuration>
+<routing-options>
+<static>
+<route inactive="inactive">
+<name>192.0.2.0/24</name>
+<discard/>
+</route>
+<route>
+<name>192.0.2.128/25</name>
+<next-hop>198.51.100.1</next-hop>
+<next-hop inactive="inactive">198.51.100.2</next-hop>
+<qualified-next-hop inactive="inactive">
+<name>198.51.100.3</name>
+<preference>7</preference>
+</qualified-next-hop>
+<preference inactive="inactive">
+<metric-value>12</metric-value>
+</preference>
+</route>
+</static>
+</routing-options>
+</configuration>`
+
+func TestDecodeConfigXMLInactive(t *testing.T) {
+	var config staticRouteXML
+	found, err := decodeConfigXML(testConfigXMLStaticRouteInactive,
+		staticRouteXMLPath("192.0.2.0/24", defaultWord), &config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found || bool(config.Discard) {
+		t.Errorf("inactive static route read: found %t, %+v", found, config)
+	}
+	found, err = decodeConfigXML(testConfigXMLStaticRouteInactive,
+		staticRouteXMLPath("192.0.2.128/25", defaultWord), &config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found {
+		t.Fatal("static route not found")
+	}
+	if len(config.NextHop) != 1 || config.NextHop[0] != "198.51.100.1" {
+		t.Errorf("unexpected next-hop %q", config.NextHop)
+	}
+	if len(config.QualifiedNextHop) != 0 || config.Preference != 0 {
+		t.Errorf("inactive statements read: %+v", config)
+	}
+}
+
+func TestRemoveDeactivatedConfigSetLines(t *testing.T) {
+	lines := []string{
+		"next-hop 198.51.100.1",
+		"next-hop 198.51.100.2",
+		"qualified-next-hop 198.51.100.3 preference 7",
+		"community \"65000:100\"",
+		"preference 12",
+	}
+	activeLines := removeDeactivatedConfigSetLines(lines, [][]string{
+		splitConfigSetWords(" next-hop 198.51.100.2"),
+		splitConfigSetWords(" qualified-next-hop 198.51.100.3"),
+		splitConfigSetWords(" community 65000:100"),
+	})
+	expected := []string{"next-hop 198.51.100.1", "preference 12"}
+	if strings.Join(activeLines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("unexpected active lines %q, want %q", activeLines, expected)
+	}
+	if activeLines := removeDeactivatedConfigSetLines(lines, [][]string{splitConfigSetWords("")}); len(activeLines) != 0 {
+		t.Errorf("lines of a deactivated hierarchy kept: %q", activeLines)
+	}
+}
@@ -0,0 +1,334 @@
+package junos
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// xmlNode is an element of a generic xml tree (without schema) of a rpc-reply.
+type xmlNode struct {
+	name     string
+	attrs    map[string]string
+	text     string
+	children []*xmlNode
+}
+
+// xmlPathPredicate is a predicate of a step in xpath like `[name='ge-0/0/0']`, `[@style='brief']` or `[1]`.
+type xmlPathPredicate struct {
+	position int
+	key      string
+	value    string
+	hasValue bool
+}
+
+// parseXMLTree returns a document node with the elements of data in children.
+// The namespaces of elements and attributes are removed.
+func parseXMLTree(data string) (*xmlNode, error) {
+	document := &xmlNode{}
+	stack := []*xmlNode{document}
+	decoder := xml.NewDecoder(strings.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to xml decode reply : %w", err)
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			node := &xmlNode{name: element.Name.Local}
+			for _, attr := range element.Attr {
+				if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+					continue
+				}
+				if node.attrs == nil {
+					node.attrs = make(map[string]string)
+				}
+				node.attrs[attr.Name.Local] = attr.Value
+			}
+			parent := stack[len(stack)-1]
+			parent.children = append(parent.children, node)
+			stack = append(stack, node)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			stack[len(stack)-1].text += string(element)
+		}
+	}
+	var trimText func(node *xmlNode)
+	trimText = func(node *xmlNode) {
+		node.text = strings.TrimSpace(node.text)
+		for _, child := range node.children {
+			trimText(child)
+		}
+	}
+	trimText(document)
+
+	return document, nil
+}
+
+// json returns the node in JSON: an element without attributes and children is a string,
+// other elements are objects with attributes (prefixed by `@`), children (in array if repeated)
+// and text (with `#text` key).
+func (n *xmlNode) json() (string, error) {
+	output, err := json.Marshal(n.jsonValue())
+	if err != nil {
+		return "", fmt.Errorf("failed to json marshal reply : %w", err)
+	}
+
+	return string(output), nil
+}
+
+func (n *xmlNode) jsonValue() interface{} {
+	if len(n.attrs) == 0 && len(n.children) == 0 {
+		return n.text
+	}
+	object := make(map[string]interface{})
+	for k, v := range n.attrs {
+		object["@"+k] = v
+	}
+	for _, child := range n.children {
+		switch v := object[child.name].(type) {
+		case nil:
+			object[child.name] = child.jsonValue()
+		case []interface{}:
+			object[child.name] = append(v, child.jsonValue())
+		default:
+			object[child.name] = []interface{}{v, child.jsonValue()}
+		}
+	}
+	if n.text != "" {
+		object["#text"] = n.text
+	}
+
+	return object
+}
+
+// xpath returns the values selected by a subset of xpath expressions on node:
+// steps with element names (or `*`) separated by `/` (or `//` for descendants),
+// predicates `[child='value']`, `[@attr='value']`, `[text()='value']`, `[child]`, `[@attr]`, `[position]`
+// and a last step `text()` or `@attr` (the text of elements is returned by default).
+func (n *xmlNode) xpath(expression string) ([]string, error) {
+	expression = strings.TrimSpace(expression)
+	steps, err := splitXMLPathSteps(expression)
+	if err != nil {
+		return nil, err
+	}
+	nodes := []*xmlNode{n}
+	descendant := false
+	for i, step := range steps {
+		switch {
+		case step == "":
+			descendant = true
+
+			continue
+		case step == "text()", strings.HasPrefix(step, "@"):
+			if i != len(steps)-1 {
+				return nil, fmt.Errorf("`%s` needs to be the last step of xpath `%s`", step, expression)
+			}
+			if descendant {
+				nodes = xmlPathCandidates(nodes, true)
+			}
+			values := make([]string, 0, len(nodes))
+			for _, node := range nodes {
+				if step == "text()" {
+					values = append(values, node.text)
+				} else if v, ok := node.attrs[strings.TrimPrefix(step, "@")]; ok {
+					values = append(values, v)
+				}
+			}
+
+			return values, nil
+		}
+		name, predicates, err := parseXMLPathStep(step)
+		if err != nil {
+			return nil, fmt.Errorf("%w in xpath `%s`", err, expression)
+		}
+		next := make([]*xmlNode, 0)
+		for _, node := range nodes {
+			matches := make([]*xmlNode, 0)
+			for _, candidate := range xmlPathCandidates([]*xmlNode{node}, descendant) {
+				if name == "*" || candidate.name == name {
+					matches = append(matches, candidate)
+				}
+			}
+			for _, predicate := range predicates {
+				matches = predicate.filter(matches)
+			}
+			next = append(next, matches...)
+		}
+		nodes = next
+		descendant = false
+	}
+	values := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		values = append(values, node.text)
+	}
+
+	return values, nil
+}
+
+// xmlPathCandidates returns the children (or the descendants) of nodes.
+func xmlPathCandidates(nodes []*xmlNode, descendant bool) []*xmlNode {
+	result := make([]*xmlNode, 0)
+	for _, node := range nodes {
+		for _, child := range node.children {
+			result = append(result, child)
+			if descendant {
+				result = append(result, xmlPathCandidates([]*xmlNode{child}, true)...)
+			}
+		}
+	}
+
+	return result
+}
+
+func (p xmlPathPredicate) filter(nodes []*xmlNode) []*xmlNode {
+	if p.position > 0 {
+		if p.position > len(nodes) {
+			return nil
+		}
+
+		return nodes[p.position-1 : p.position]
+	}
+	result := make([]*xmlNode, 0, len(nodes))
+	for _, node := range nodes {
+		values := make([]string, 0)
+		switch {
+		case p.key == "text()":
+			values = append(values, node.text)
+		case strings.HasPrefix(p.key, "@"):
+			if v, ok := node.attrs[strings.TrimPrefix(p.key, "@")]; ok {
+				values = append(values, v)
+			}
+		default:
+			for _, child := range node.children {
+				if child.name == p.key {
+					values = append(values, child.text)
+				}
+			}
+		}
+		for _, v := range values {
+			if !p.hasValue || v == p.value {
+				result = append(result, node)
+
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// splitXMLPathSteps splits a xpath expression in steps (outside predicates),
+// an empty step is a `//` separator.
+func splitXMLPathSteps(expression string) ([]string, error) {
+	steps := make([]string, 0)
+	switch {
+	case strings.HasPrefix(expression, "//"):
+		steps = append(steps, "")
+		expression = expression[2:]
+	case strings.HasPrefix(expression, "/"):
+		expression = expression[1:]
+	}
+	var step strings.Builder
+	var quote rune
+	depth := 0
+	for _, r := range expression {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '[':
+			depth++
+		case r == ']':
+			depth--
+		case r == '/' && depth == 0:
+			switch {
+			case step.Len() > 0:
+				steps = append(steps, step.String())
+				step.Reset()
+			case len(steps) > 0 && steps[len(steps)-1] != "":
+				steps = append(steps, "")
+			default:
+				return nil, fmt.Errorf("xpath `%s` has an empty step", expression)
+			}
+
+			continue
+		}
+		step.WriteRune(r)
+	}
+	if quote != 0 || depth != 0 {
+		return nil, fmt.Errorf("xpath `%s` has an unterminated quote or predicate", expression)
+	}
+	if step.Len() == 0 {
+		return nil, fmt.Errorf("xpath `%s` ends without step", expression)
+	}
+
+	return append(steps, step.String()), nil
+}
+
+// parseXMLPathStep returns the element name and the predicates of a step.
+func parseXMLPathStep(step string) (string, []xmlPathPredicate, error) {
+	name := step
+	predicates := make([]xmlPathPredicate, 0)
+	if i := strings.Index(step, "["); i != -1 {
+		name = step[:i]
+		rest := step[i:]
+		for rest != "" {
+			end := strings.Index(rest, "]")
+			if !strings.HasPrefix(rest, "[") || end == -1 {
+				return "", nil, fmt.Errorf("unsupported predicate `%s`", rest)
+			}
+			predicate, err := parseXMLPathPredicate(strings.TrimSpace(rest[1:end]))
+			if err != nil {
+				return "", nil, err
+			}
+			predicates = append(predicates, predicate)
+			rest = rest[end+1:]
+		}
+	}
+	if name == "" || strings.ContainsAny(name, " ()|=@'\".") {
+		return "", nil, fmt.Errorf("unsupported step `%s`", step)
+	}
+
+	return name, predicates, nil
+}
+
+func parseXMLPathPredicate(predicate string) (xmlPathPredicate, error) {
+	if position, err := strconv.Atoi(predicate); err == nil {
+		if position < 1 {
+			return xmlPathPredicate{}, fmt.Errorf("unsupported position `%s` in predicate", predicate)
+		}
+
+		return xmlPathPredicate{position: position}, nil
+	}
+	key := predicate
+	result := xmlPathPredicate{}
+	if i := strings.Index(predicate, "="); i != -1 {
+		key = strings.TrimSpace(predicate[:i])
+		value := strings.TrimSpace(predicate[i+1:])
+		if len(value) < 2 || (value[0] != '\'' && value[0] != '"') || value[len(value)-1] != value[0] {
+			return xmlPathPredicate{}, fmt.Errorf("unsupported value `%s` in predicate (need quotes)", value)
+		}
+		result.value = value[1 : len(value)-1]
+		result.hasValue = true
+	}
+	if key == "" || strings.ContainsAny(key, " []/'\"") {
+		return xmlPathPredicate{}, fmt.Errorf("unsupported predicate `%s`", predicate)
+	}
+	result.key = key
+
+	return result, nil
+}
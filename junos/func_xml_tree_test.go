@@ -0,0 +1,90 @@
+package junos
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestXMLTreeXPath(t *testing.T) {
+	reply, err := parseXMLTree(`
+<route-information xmlns="http://xml.juniper.net/junos/18.4R1/junos-routing">
+  <route-table>
+    <table-name>inet.0</table-name>
+    <total-route-count>2</total-route-count>
+    <rt junos:style="brief">
+      <rt-destination>192.0.2.0/24</rt-destination>
+      <rt-entry><protocol-name>Static</protocol-name><nh><to>198.51.100.1</to></nh></rt-entry>
+    </rt>
+    <rt junos:style="brief">
+      <rt-destination>198.51.100.0/24</rt-destination>
+      <rt-entry><protocol-name>Direct</protocol-name></rt-entry>
+    </rt>
+  </route-table>
+</route-information>`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]string{
+		"route-information/route-table/total-route-count":                       "2",
+		"/route-information/route-table/rt/rt-destination":                      "192.0.2.0/24\n198.51.100.0/24",
+		"//rt[rt-destination='192.0.2.0/24']//to":                               "198.51.100.1",
+		"//rt[rt-entry/protocol-name='Direct']/rt-destination":                  "error",
+		"//rt-entry[protocol-name='Direct']/../rt-destination":                  "error",
+		"//rt[2]/rt-destination/text()":                                         "198.51.100.0/24",
+		"//rt[@style=\"brief\"][1]/@style":                                      "brief",
+		"//*[text()='Static']":                                                  "Static",
+		"//route-table/":                                                        "error",
+		"//rt[rt-destination='192.0.2.0/24'":                                    "error",
+		"route-information/route-table/rt/rt-entry/protocol-name[text()='Dir']": "",
+	}
+	for expression, expected := range tests {
+		values, err := reply.xpath(expression)
+		switch {
+		case expected == "error":
+			if err == nil {
+				t.Errorf("xpath %q: error expected, got %q", expression, values)
+			}
+		case err != nil:
+			t.Errorf("xpath %q: unexpected error %s", expression, err)
+		default:
+			if v := strings.Join(values, "\n"); v != expected {
+				t.Errorf("xpath %q: got %q, expected %q", expression, v, expected)
+			}
+		}
+	}
+	replyJSON, err := reply.json()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedJSON := `{"route-information":{"route-table":{"rt":[` +
+		`{"@style":"brief","rt-destination":"192.0.2.0/24",` +
+		`"rt-entry":{"nh":{"to":"198.51.100.1"},"protocol-name":"Static"}},` +
+		`{"@style":"brief","rt-destination":"198.51.100.0/24","rt-entry":{"protocol-name":"Direct"}}],` +
+		`"table-name":"inet.0","total-route-count":"2"}}}`
+	if replyJSON != expectedJSON {
+		t.Errorf("unexpected json:\n%s\nexpected:\n%s", replyJSON, expectedJSON)
+	}
+}
+
+func TestRPCFromArguments(t *testing.T) {
+	rpc, err := rpcFromArguments("get-route-information", map[string]interface{}{
+		"table":       "inet.0",
+		"destination": "192.0.2.0/24",
+		"detail":      "",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "<get-route-information><destination>192.0.2.0/24</destination>" +
+		"<detail/><table>inet.0</table></get-route-information>"; rpc != expected {
+		t.Errorf("unexpected rpc %q, expected %q", rpc, expected)
+	}
+	for _, name := range []string{"request-system-reboot", "clear-bgp-neighbor", "lock", "load-configuration"} {
+		if _, err := rpcFromArguments(name, nil); err == nil {
+			t.Errorf("rpc %q accepted", name)
+		}
+	}
+	if _, err := rpcFromArguments("get-route-information", map[string]interface{}{"<table>": ""}); err == nil {
+		t.Errorf("argument with invalid name accepted")
+	}
+}
@@ -0,0 +1,115 @@
+// Package fakedevice is an in-memory Junos device answering to the netconf rpc used by the provider,
+// to test the provider without device.
+//
+// The configuration (committed and candidate) is a setconfig.Store: a list of `set` lines without schema.
+package fakedevice
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/jeremmfr/terraform-provider-junos/junos/internal/setconfig"
+)
+
+const (
+	defaultHostName  = "fake"
+	defaultOSVersion = "21.4R1"
+)
+
+// Device is an in-memory Junos device shared by its netconf sessions.
+type Device struct {
+	*setconfig.Store
+	mutex        sync.Mutex
+	clusterNode  bool
+	routeEngines int
+	model        string
+	osVersion    string
+	interfaces   []string
+	commits      []string
+	synchronized []string
+	rejected     []rejectedStatement
+}
+
+// rejectedStatement is a statement refused by the commit (and the commit check) of device.
+type rejectedStatement struct {
+	statement string
+	message   string
+}
+
+// New returns a device with an empty configuration which reports the hardware model.
+func New(model string) *Device {
+	d := &Device{
+		model:        model,
+		osVersion:    defaultOSVersion,
+		routeEngines: 1,
+		interfaces:   []string{"ge-0/0/0", "ge-0/0/1", "ge-0/0/2", "ge-0/0/3"},
+	}
+	d.Store = setconfig.New(deviceRPC{d})
+
+	return d
+}
+
+// Model returns the hardware model reported by device.
+func (d *Device) Model() string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.model
+}
+
+// SetInterfaces replaces the physical interfaces present on device.
+func (d *Device) SetInterfaces(names ...string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.interfaces = append([]string{}, names...)
+}
+
+// SetRouteEngines sets the number of routing engines reported by device.
+func (d *Device) SetRouteEngines(count int) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.routeEngines = count
+}
+
+// SetClusterNode sets if device reports to be a node of chassis cluster.
+func (d *Device) SetClusterNode(clusterNode bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.clusterNode = clusterNode
+}
+
+// RejectStatement refuses the commit (and the commit check) of a candidate configuration
+// with a line which contains statement, with the message in error.
+func (d *Device) RejectStatement(statement, message string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.rejected = append(d.rejected, rejectedStatement{statement: statement, message: message})
+}
+
+// Commits returns the log message of each commit (without check) in order.
+func (d *Device) Commits() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return append([]string{}, d.commits...)
+}
+
+// SynchronizedCommits returns the log message of each commit synchronized
+// on the other routing engines (with `synchronize`) in order.
+func (d *Device) SynchronizedCommits() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return append([]string{}, d.synchronized...)
+}
+
+// hostName returns the host-name of committed configuration.
+func hostName(committed []string) string {
+	for _, line := range committed {
+		if strings.HasPrefix(line, "set system host-name ") {
+			return strings.Trim(strings.TrimPrefix(line, "set system host-name "), "\"")
+		}
+	}
+
+	return defaultHostName
+}
@@ -0,0 +1,117 @@
+package fakedevice
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jeremmfr/terraform-provider-junos/junos/internal/setconfig"
+)
+
+// deviceRPC answers to the rpc of device which aren't about the configuration.
+type deviceRPC struct {
+	device *Device
+}
+
+// Operational returns the reply of system, interfaces and routing engines information.
+func (r deviceRPC) Operational(name, _ string, committed []string) (string, []setconfig.Error) {
+	d := r.device
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	switch name {
+	case "get-system-information":
+		return "<system-information>" +
+			"<hardware-model>" + setconfig.Escape(d.model) + "</hardware-model>" +
+			"<os-name>junos</os-name>" +
+			"<os-version>" + setconfig.Escape(d.osVersion) + "</os-version>" +
+			"<serial-number>FAKE0000</serial-number>" +
+			"<host-name>" + setconfig.Escape(hostName(committed)) + "</host-name>" +
+			clusterNode(d.clusterNode) +
+			"</system-information>", nil
+	case "get-interface-information":
+		return d.interfaceInformation(committed), nil
+	case "get-route-engine-information":
+		return d.routeEngineInformation(), nil
+	default:
+		return "", setconfig.Errors(setconfig.SeverityError, "syntax error")
+	}
+}
+
+// CheckCommit returns the errors of commit for the lines of candidate configuration with rejected statements.
+func (r deviceRPC) CheckCommit(candidate []string) []setconfig.Error {
+	d := r.device
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	errs := make([]setconfig.Error, 0)
+	for _, line := range candidate {
+		for _, rejected := range d.rejected {
+			if !strings.Contains(line, rejected.statement) {
+				continue
+			}
+			words := strings.Fields(strings.TrimPrefix(line, "set "))
+			errs = append(errs, setconfig.Error{
+				Severity:   setconfig.SeverityError,
+				Message:    rejected.message,
+				Path:       "[edit " + strings.Join(words[:len(words)-1], " ") + "]",
+				BadElement: words[len(words)-1],
+			})
+		}
+	}
+
+	return errs
+}
+
+// Commit records the commit (and its synchronization) and returns the results of routing engines.
+func (r deviceRPC) Commit(log string, check, synchronize bool) string {
+	d := r.device
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if check {
+		return "<routing-engine><name>re0</name><commit-check-success/></routing-engine>"
+	}
+	d.commits = append(d.commits, log)
+	results := "<routing-engine><name>re0</name><commit-success/></routing-engine>"
+	if synchronize {
+		d.synchronized = append(d.synchronized, log)
+		for i := 1; i < d.routeEngines; i++ {
+			results += fmt.Sprintf("<routing-engine><name>re%d</name><commit-success/></routing-engine>", i)
+		}
+	}
+
+	return results
+}
+
+func (d *Device) interfaceInformation(committed []string) string {
+	var info strings.Builder
+	info.WriteString("<interface-information>")
+	for _, name := range d.interfaces {
+		adminStatus := "up"
+		if len(setconfig.FilterLines(committed, "interfaces "+name+" disable", false)) > 0 {
+			adminStatus = "down"
+		}
+		info.WriteString("<physical-interface><name>" + setconfig.Escape(name) + "</name>" +
+			"<admin-status>" + adminStatus + "</admin-status><oper-status>" + adminStatus + "</oper-status>" +
+			"</physical-interface>")
+	}
+	info.WriteString("</interface-information>")
+
+	return info.String()
+}
+
+func (d *Device) routeEngineInformation() string {
+	var info strings.Builder
+	info.WriteString("<route-engine-information>")
+	for i := 0; i < d.routeEngines; i++ {
+		info.WriteString(fmt.Sprintf("<route-engine><slot>%d</slot></route-engine>", i))
+	}
+	info.WriteString("</route-engine-information>")
+
+	return info.String()
+}
+
+func clusterNode(clusterNode bool) string {
+	if !clusterNode {
+		return ""
+	}
+
+	return "<cluster-node>true</cluster-node>"
+}
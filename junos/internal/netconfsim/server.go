@@ -0,0 +1,256 @@
+// Package netconfsim is a local SSH server with the netconf subsystem answering like a Junos device
+// (with an in-memory fake device), to run the acceptance tests without device.
+package netconfsim
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/jeremmfr/terraform-provider-junos/junos/internal/fakedevice"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	msgSeparator = "]]>]]>"
+
+	helloFormat = `<?xml version="1.0" encoding="UTF-8"?>
+<hello xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">
+<capabilities>
+<capability>urn:ietf:params:netconf:base:1.0</capability>
+<capability>urn:ietf:params:netconf:capability:candidate:1.0</capability>
+<capability>urn:ietf:params:netconf:capability:confirmed-commit:1.0</capability>
+<capability>http://xml.juniper.net/netconf/junos/1.0</capability>
+</capabilities>
+<session-id>%d</session-id>
+</hello>`
+)
+
+// Server is a local SSH server with the netconf subsystem connected to a fake device.
+type Server struct {
+	listener   net.Listener
+	config     *ssh.ServerConfig
+	device     *fakedevice.Device
+	hostKey    ssh.PublicKey
+	waitGroup  sync.WaitGroup
+	mutex      sync.Mutex
+	lastID     int
+	connsAlive map[net.Conn]struct{}
+}
+
+// rpcMessage is a rpc received from client.
+type rpcMessage struct {
+	XMLName   xml.Name `xml:"rpc"`
+	MessageID string   `xml:"message-id,attr"`
+	Method    string   `xml:",innerxml"`
+}
+
+// Start listens on a random port of 127.0.0.1 and serves the netconf subsystem over SSH
+// for device with the username and password.
+func Start(device *fakedevice.Device, username, password string) (*Server, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate host key : %w", err)
+	}
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signer of host key : %w", err)
+	}
+	checkPassword := func(user, pass string) error {
+		if user != username || pass != password {
+			return errors.New("authentication failed")
+		}
+
+		return nil
+	}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			return nil, checkPassword(conn.User(), string(pass))
+		},
+		KeyboardInteractiveCallback: func(
+			conn ssh.ConnMetadata, challenge ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+			answers, err := challenge("", "", []string{"Password:"}, []bool{false})
+			if err != nil {
+				return nil, err
+			}
+			if len(answers) != 1 {
+				return nil, errors.New("authentication failed")
+			}
+
+			return nil, checkPassword(conn.User(), answers[0])
+		},
+	}
+	config.AddHostKey(signer)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen : %w", err)
+	}
+	server := &Server{
+		listener:   listener,
+		config:     config,
+		device:     device,
+		hostKey:    signer.PublicKey(),
+		connsAlive: make(map[net.Conn]struct{}),
+	}
+	server.waitGroup.Add(1)
+	go server.accept()
+
+	return server, nil
+}
+
+// Device returns the fake device behind server.
+func (s *Server) Device() *fakedevice.Device {
+	return s.device
+}
+
+// Port returns the listening port of server.
+func (s *Server) Port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// HostKeyFingerprint returns the SHA256 fingerprint of the host key of server.
+func (s *Server) HostKeyFingerprint() string {
+	return ssh.FingerprintSHA256(s.hostKey)
+}
+
+// Close stops listening, closes the connections in progress and waits for the end of them.
+func (s *Server) Close() error {
+	err := s.listener.Close()
+	s.mutex.Lock()
+	for conn := range s.connsAlive {
+		conn.Close()
+	}
+	s.mutex.Unlock()
+	s.waitGroup.Wait()
+
+	return err
+}
+
+func (s *Server) accept() {
+	defer s.waitGroup.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.mutex.Lock()
+		s.connsAlive[conn] = struct{}{}
+		s.mutex.Unlock()
+		s.waitGroup.Add(1)
+		go func() {
+			defer s.waitGroup.Done()
+			s.serveConn(conn)
+			s.mutex.Lock()
+			delete(s.connsAlive, conn)
+			s.mutex.Unlock()
+		}()
+	}
+}
+
+func (s *Server) serveConn(conn net.Conn) {
+	defer conn.Close()
+	sshConn, channels, requests, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		return
+	}
+	defer sshConn.Close()
+	go ssh.DiscardRequests(requests)
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go s.serveChannel(channel, channelRequests)
+	}
+}
+
+// serveChannel waits for the request of netconf subsystem and serves it on channel.
+func (s *Server) serveChannel(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+	for req := range requests {
+		if req.Type != "subsystem" || !isNetconfSubsystem(req.Payload) {
+			_ = req.Reply(false, nil)
+
+			continue
+		}
+		_ = req.Reply(true, nil)
+		go ssh.DiscardRequests(requests)
+		s.serveNetconf(channel)
+
+		return
+	}
+}
+
+// isNetconfSubsystem decodes the payload of subsystem request (a string with its length).
+func isNetconfSubsystem(payload []byte) bool {
+	var subsystem struct {
+		Name string
+	}
+	if err := ssh.Unmarshal(payload, &subsystem); err != nil {
+		return false
+	}
+
+	return subsystem.Name == "netconf"
+}
+
+func (s *Server) serveNetconf(channel ssh.Channel) {
+	session := s.device.NewSession()
+	defer session.Close()
+	s.mutex.Lock()
+	s.lastID++
+	sessionID := s.lastID
+	s.mutex.Unlock()
+	if _, err := channel.Write([]byte(fmt.Sprintf(helloFormat, sessionID) + msgSeparator)); err != nil {
+		return
+	}
+	reader := bufio.NewReader(channel)
+	var msg bytes.Buffer
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return
+		}
+		msg.WriteByte(b)
+		if !bytes.HasSuffix(msg.Bytes(), []byte(msgSeparator)) {
+			continue
+		}
+		data := bytes.TrimSuffix(msg.Bytes(), []byte(msgSeparator))
+		msg.Reset()
+		if bytes.Contains(data, []byte("<hello")) {
+			continue
+		}
+		var rpc rpcMessage
+		if err := xml.Unmarshal(data, &rpc); err != nil {
+			// like Junos, reply with an error to a message not well-formed
+			if _, err := channel.Write([]byte(session.Reply("") + msgSeparator)); err != nil {
+				return
+			}
+
+			continue
+		}
+		reply := session.Reply(strings.TrimSpace(rpc.Method))
+		if rpc.MessageID != "" {
+			var messageID strings.Builder
+			_ = xml.EscapeText(&messageID, []byte(rpc.MessageID))
+			reply = strings.Replace(reply, "<rpc-reply ", "<rpc-reply message-id=\""+messageID.String()+"\" ", 1)
+		}
+		if _, err := channel.Write([]byte(reply + msgSeparator)); err != nil {
+			return
+		}
+		if strings.Contains(rpc.Method, "<close-session") {
+			return
+		}
+	}
+}
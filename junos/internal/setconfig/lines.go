@@ -0,0 +1,115 @@
+// Package setconfig is an in-memory Junos configuration stored as a list of `set` lines without schema,
+// with netconf sessions which answer to the rpc of configuration used by the provider
+// (`show configuration`, lock, load-configuration, commit...).
+//
+// A `set` line is added if not already present and a `delete` line removes the lines under its path.
+package setconfig
+
+import (
+	"strings"
+)
+
+// applyLine applies a `set` or `delete` line on a list of `set` lines.
+// Return false if the line isn't a valid `set` or `delete` line.
+func applyLine(lines []string, line string) ([]string, bool) {
+	words := strings.Fields(line)
+	if len(words) < 2 {
+		return lines, false
+	}
+	path := strings.Join(words[1:], " ")
+	switch words[0] {
+	case "set":
+		for _, v := range lines {
+			if v == "set "+path {
+				return lines, true
+			}
+		}
+
+		return append(lines, "set "+path), true
+	case "delete":
+		result := make([]string, 0, len(lines))
+		for _, v := range lines {
+			if v == "set "+path || strings.HasPrefix(v, "set "+path+" ") {
+				continue
+			}
+			result = append(result, v)
+		}
+
+		return result, true
+	default:
+		return lines, false
+	}
+}
+
+// FilterLines returns the `set` lines under path (relative to path if needed)
+// like `show configuration <path> | display set [relative]`.
+func FilterLines(lines []string, path string, relative bool) []string {
+	result := make([]string, 0)
+	for _, line := range lines {
+		statement := strings.TrimPrefix(line, "set ")
+		switch {
+		case path == "":
+			result = append(result, line)
+		case statement == path:
+			if relative {
+				result = append(result, "set")
+			} else {
+				result = append(result, line)
+			}
+		case strings.HasPrefix(statement, path+" "):
+			if relative {
+				result = append(result, "set "+strings.TrimPrefix(statement, path+" "))
+			} else {
+				result = append(result, line)
+			}
+		}
+	}
+
+	return result
+}
+
+// compareLines returns the differences between two configurations (like `show | compare`)
+// with the statements added (+) and removed (-).
+func compareLines(from, to []string) string {
+	var compare strings.Builder
+	inFrom := make(map[string]bool, len(from))
+	for _, line := range from {
+		inFrom[line] = true
+	}
+	inTo := make(map[string]bool, len(to))
+	for _, line := range to {
+		inTo[line] = true
+	}
+	for _, line := range from {
+		if !inTo[line] {
+			compare.WriteString("-  " + strings.TrimPrefix(line, "set ") + "\n")
+		}
+	}
+	for _, line := range to {
+		if !inFrom[line] {
+			compare.WriteString("+  " + strings.TrimPrefix(line, "set ") + "\n")
+		}
+	}
+	if compare.Len() == 0 {
+		return ""
+	}
+
+	return "[edit]\n" + compare.String()
+}
+
+func copyLines(lines []string) []string {
+	return append(make([]string, 0, len(lines)), lines...)
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,388 @@
+package setconfig
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/jeremmfr/go-netconf/netconf"
+)
+
+// Severities of Error.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+const (
+	showConfigurationWord  = "show configuration"
+	displaySetWord         = "display set"
+	displaySetRelativeWord = "display set relative"
+)
+
+var errSessionClosed = errors.New("netconf session on set configuration closed") // nolint: gochecknoglobals
+
+// Device answers to the rpc which aren't about the configuration (like get-system-information)
+// and completes the commits of a Store.
+// Its methods are called with the lock of store.
+type Device interface {
+	// Operational returns the body of rpc-reply for the rpc named name (the raw rpc in rpc)
+	// with the committed configuration.
+	Operational(name, rpc string, committed []string) (string, []Error)
+	// CheckCommit returns the errors which refuse the commit (or the commit check) of candidate.
+	CheckCommit(candidate []string) []Error
+	// Commit returns the results of routing engines (<routing-engine> elements) for a commit
+	// (or a commit check) with the log message.
+	Commit(log string, check, synchronize bool) string
+}
+
+// Error is an error (or a warning) returned in a rpc-reply.
+type Error struct {
+	Severity   string
+	Message    string
+	Path       string
+	BadElement string
+}
+
+// Store is an in-memory configuration (committed and candidate) shared by its netconf sessions.
+type Store struct {
+	mutex     sync.Mutex
+	lastID    int
+	lockedBy  *Session
+	committed []string
+	candidate []string
+	device    Device
+}
+
+// Session is a netconf session on a Store.
+type Session struct {
+	closed    bool
+	private   bool
+	id        int
+	candidate []string
+	store     *Store
+}
+
+// request is the generic decoding of a rpc sent by the provider.
+type request struct {
+	XMLName          xml.Name
+	Action           string     `xml:"action,attr"`
+	Compare          string     `xml:"compare,attr"`
+	Database         string     `xml:"database,attr"`
+	Format           string     `xml:"format,attr"`
+	Text             string     `xml:",chardata"`
+	ConfigurationSet string     `xml:"configuration-set"`
+	Configuration    *xmlFilter `xml:"configuration"`
+	Log              string     `xml:"log"`
+	Check            *struct{}  `xml:"check"`
+	Private          *struct{}  `xml:"private"`
+	Synchronize      *struct{}  `xml:"synchronize"`
+}
+
+// New returns a store with an empty configuration and device for the other rpc.
+func New(device Device) *Store {
+	return &Store{
+		committed: make([]string, 0),
+		candidate: make([]string, 0),
+		device:    device,
+	}
+}
+
+// LoadCommitted loads `set` (or `delete`) lines in the committed configuration
+// (and in the shared candidate configuration).
+func (s *Store) LoadCommitted(lines ...string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, line := range lines {
+		s.committed, _ = applyLine(s.committed, line)
+	}
+	s.candidate = copyLines(s.committed)
+}
+
+// Committed returns the `set` lines of the committed configuration.
+func (s *Store) Committed() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyLines(s.committed)
+}
+
+// Candidate returns the `set` lines of the shared candidate configuration.
+func (s *Store) Candidate() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return copyLines(s.candidate)
+}
+
+// NewSession opens a new netconf session on store.
+func (s *Store) NewSession() *Session {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.lastID++
+
+	return &Session{
+		id:    s.lastID,
+		store: s,
+	}
+}
+
+// Exec sends rpc to the store and returns the reply like a netconf.Session.
+func (s *Session) Exec(methods ...netconf.RPCMethod) (*netconf.RPCReply, error) {
+	var rpc strings.Builder
+	for _, method := range methods {
+		rpc.WriteString(method.MarshalMethod())
+	}
+	s.store.mutex.Lock()
+	closed := s.closed
+	s.store.mutex.Unlock()
+	if closed {
+		return nil, errSessionClosed
+	}
+	rawReply := s.Reply(rpc.String())
+	reply := &netconf.RPCReply{RawReply: rawReply}
+	if err := xml.Unmarshal([]byte(rawReply), reply); err != nil {
+		return nil, fmt.Errorf("failed to xml unmarshal reply : %w", err)
+	}
+	for i := range reply.Errors {
+		if reply.Errors[i].Severity == SeverityError {
+			return reply, &reply.Errors[i]
+		}
+	}
+
+	return reply, nil
+}
+
+// Close closes the session, the lock of candidate configuration is released and
+// its uncommitted changes are discarded.
+func (s *Session) Close() error {
+	s.store.mutex.Lock()
+	defer s.store.mutex.Unlock()
+	s.release()
+
+	return nil
+}
+
+// Reply returns the raw rpc-reply of store for a rpc (without the <rpc> element).
+func (s *Session) Reply(rpc string) string {
+	s.store.mutex.Lock()
+	defer s.store.mutex.Unlock()
+	var req request
+	if err := xml.Unmarshal([]byte(rpc), &req); err != nil {
+		return rpcReply("", Error{Severity: SeverityError, Message: "syntax error"})
+	}
+	body, errs := s.handle(req, rpc)
+
+	return rpcReply(body, errs...)
+}
+
+func (s *Session) handle(req request, rpc string) (string, []Error) {
+	switch req.XMLName.Local {
+	case "command":
+		return s.command(strings.TrimSpace(req.Text))
+	case "get-configuration":
+		return s.getConfiguration(req)
+	case "load-configuration":
+		return s.loadConfiguration(req)
+	case "lock":
+		return s.lock()
+	case "unlock":
+		if s.store.lockedBy != s {
+			return "", Errors(SeverityError, "configuration database not locked")
+		}
+		s.store.lockedBy = nil
+
+		return "<ok/>", nil
+	case "open-configuration":
+		if req.Private == nil {
+			return "", Errors(SeverityError, "only private configuration is supported")
+		}
+		s.private = true
+		s.candidate = copyLines(s.store.committed)
+
+		return "<ok/>", nil
+	case "close-configuration":
+		s.private = false
+		s.candidate = nil
+
+		return "<ok/>", nil
+	case "delete-config":
+		*s.candidateLines() = copyLines(s.store.committed)
+
+		return "<ok/>", nil
+	case "commit-configuration":
+		return s.commit(req)
+	case "close-session":
+		s.release()
+
+		return "<ok/>", nil
+	default:
+		return s.store.device.Operational(req.XMLName.Local, rpc, s.store.committed)
+	}
+}
+
+// candidateLines returns the candidate configuration used by session (private or shared).
+func (s *Session) candidateLines() *[]string {
+	if s.private {
+		return &s.candidate
+	}
+
+	return &s.store.candidate
+}
+
+func (s *Session) command(cmd string) (string, []Error) {
+	cmdSplit := strings.Split(strings.TrimPrefix(cmd, showConfigurationWord), "|")
+	if !strings.HasPrefix(cmd, showConfigurationWord) || len(cmdSplit) != 2 {
+		return "", Errors(SeverityError, "syntax error, only `show configuration` commands are supported")
+	}
+	var relative bool
+	switch strings.TrimSpace(cmdSplit[1]) {
+	case displaySetWord:
+	case displaySetRelativeWord:
+		relative = true
+	default:
+		return "", Errors(SeverityError, "syntax error, only `display set` output is supported")
+	}
+	lines := FilterLines(s.store.committed, strings.Join(strings.Fields(cmdSplit[0]), " "), relative)
+	if len(lines) == 0 {
+		return "", nil
+	}
+
+	return "\n<configuration-output>\n" + Escape(strings.Join(lines, "\n")) + "\n</configuration-output>\n", nil
+}
+
+func (s *Session) getConfiguration(req request) (string, []Error) {
+	if req.Compare == "rollback" {
+		return "<configuration-information><configuration-output>\n" +
+			Escape(compareLines(s.store.committed, *s.candidateLines())) +
+			"</configuration-output></configuration-information>", nil
+	}
+	lines := s.store.committed
+	if req.Database == "candidate" {
+		lines = *s.candidateLines()
+	}
+	switch req.Format {
+	case "set":
+		return "<configuration-set>" + Escape(strings.Join(lines, "\n")) + "\n</configuration-set>", nil
+	case "xml":
+		if req.Configuration == nil {
+			return "", Errors(SeverityError, "format 'xml' only supported with a filter")
+		}
+		path, err := req.Configuration.path()
+		if err != nil {
+			return "", Errors(SeverityError, "syntax error in filter: "+err.Error())
+		}
+
+		return xmlConfiguration(lines, path), nil
+	default:
+		return "", Errors(SeverityError, "format '"+req.Format+"' not supported")
+	}
+}
+
+func (s *Session) loadConfiguration(req request) (string, []Error) {
+	if req.Action != "set" {
+		return "", Errors(SeverityError, "action '"+req.Action+"' not supported")
+	}
+	if !s.private && s.store.lockedBy != nil && s.store.lockedBy != s {
+		return "", Errors(SeverityError, s.lockedMessage())
+	}
+	candidate := s.candidateLines()
+	errs := make([]Error, 0)
+	for _, line := range strings.Split(req.ConfigurationSet, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		before := len(*candidate)
+		var ok bool
+		*candidate, ok = applyLine(*candidate, line)
+		switch {
+		case !ok:
+			errs = append(errs, Error{Severity: SeverityError, Message: "syntax error: " + line})
+		case strings.HasPrefix(line, "delete ") && len(*candidate) == before:
+			errs = append(errs, Error{Severity: SeverityWarning, Message: "statement not found"})
+		}
+	}
+
+	return "<load-configuration-results><ok/></load-configuration-results>", errs
+}
+
+func (s *Session) lock() (string, []Error) {
+	switch {
+	case s.store.lockedBy == s:
+		return "<ok/>", nil
+	case s.store.lockedBy != nil:
+		return "", Errors(SeverityError, s.lockedMessage())
+	case !equalLines(s.store.candidate, s.store.committed):
+		return "", Errors(SeverityError, "configuration database modified")
+	}
+	s.store.lockedBy = s
+
+	return "<ok/>", nil
+}
+
+func (s *Session) commit(req request) (string, []Error) {
+	if errs := s.store.device.CheckCommit(*s.candidateLines()); len(errs) > 0 {
+		return "", errs
+	}
+	if req.Check != nil {
+		return "<commit-results>" + s.store.device.Commit(req.Log, true, false) + "</commit-results>", nil
+	}
+	if !s.private && s.store.lockedBy != nil && s.store.lockedBy != s {
+		return "", Errors(SeverityError, s.lockedMessage())
+	}
+	s.store.committed = copyLines(*s.candidateLines())
+	if s.private && s.store.lockedBy == nil {
+		s.store.candidate = copyLines(s.store.committed)
+	}
+
+	return "<commit-results>" + s.store.device.Commit(req.Log, false, req.Synchronize != nil) + "</commit-results>", nil
+}
+
+// lockedMessage returns the message of error when the configuration is locked by another session.
+func (s *Session) lockedMessage() string {
+	return fmt.Sprintf("configuration database locked by session %d", s.store.lockedBy.id)
+}
+
+// release releases the lock of session (with discard of uncommitted changes) and closes session.
+func (s *Session) release() {
+	if s.store.lockedBy == s {
+		s.store.lockedBy = nil
+		s.store.candidate = copyLines(s.store.committed)
+	}
+	s.private = false
+	s.candidate = nil
+	s.closed = true
+}
+
+func rpcReply(body string, errs ...Error) string {
+	var reply strings.Builder
+	reply.WriteString("<rpc-reply xmlns=\"urn:ietf:params:xml:ns:netconf:base:1.0\">")
+	for _, err := range errs {
+		reply.WriteString("<rpc-error><error-type>protocol</error-type><error-tag>operation-failed</error-tag>" +
+			"<error-severity>" + err.Severity + "</error-severity>")
+		if err.Path != "" {
+			reply.WriteString("<error-path>" + Escape(err.Path) + "</error-path>")
+		}
+		if err.BadElement != "" {
+			reply.WriteString("<error-info><bad-element>" + Escape(err.BadElement) + "</bad-element></error-info>")
+		}
+		reply.WriteString("<error-message>" + Escape(err.Message) + "</error-message></rpc-error>")
+	}
+	reply.WriteString(body)
+	reply.WriteString("</rpc-reply>")
+
+	return reply.String()
+}
+
+// Errors returns a list with one error (or warning) of rpc-reply.
+func Errors(severity, message string) []Error {
+	return []Error{{Severity: severity, Message: message}}
+}
+
+// Escape escapes the text for XML like Junos (new lines are kept).
+func Escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
@@ -0,0 +1,222 @@
+package setconfig
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+// The XML elements of configuration are deduced from the words of `set` lines without schema:
+// a statement with one word is an empty element, with two words an element with a value
+// and with more words an entry of a list identified by its name.
+// The statements read in XML by the provider which don't follow these rules are listed below.
+var (
+	// xmlContainers are the statements with sub-statements but without name.
+	xmlContainers = map[string]bool{ // nolint: gochecknoglobals
+		"as-path": true,
+	}
+	// xmlEntries are the statements of a list identified by their name even without sub-statements.
+	xmlEntries = map[string]bool{ // nolint: gochecknoglobals
+		"qualified-next-hop": true,
+	}
+	// xmlValues are the statements with several values and the element of each value.
+	xmlValues = map[string][]string{ // nolint: gochecknoglobals
+		"aggregator": {"as-number", "address"},
+	}
+)
+
+// xmlElement is an element of the hierarchy of a sub-tree filter:
+// a container or an entry of a list identified by its name.
+type xmlElement struct {
+	tag  string
+	name string
+}
+
+// xmlNode is an element of configuration to generate the reply of get-configuration in XML.
+type xmlNode struct {
+	tag      string
+	name     string
+	value    string
+	hasValue bool
+	children []*xmlNode
+}
+
+// xmlFilter is the sub-tree filter of get-configuration (the <configuration> element).
+type xmlFilter struct {
+	Inner string `xml:",innerxml"`
+}
+
+// path returns the hierarchy of filter.
+// Only a filter on one hierarchy is supported.
+func (filter *xmlFilter) path() ([]xmlElement, error) {
+	path := make([]xmlElement, 0)
+	decoder := xml.NewDecoder(strings.NewReader(filter.Inner))
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "name" && len(path) > 0 {
+				var name string
+				if err := decoder.DecodeElement(&name, &t); err != nil {
+					return nil, err
+				}
+				path[len(path)-1].name = strings.TrimSpace(name)
+
+				continue
+			}
+			if depth != len(path) {
+				return nil, errors.New("filter on several hierarchies not supported")
+			}
+			path = append(path, xmlElement{tag: t.Name.Local})
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+
+	return path, nil
+}
+
+// xmlConfiguration returns the <configuration> element of the statements under path in lines.
+func xmlConfiguration(lines []string, path []xmlElement) string {
+	root := &xmlNode{tag: "configuration"}
+	words := make([]string, 0, len(path)*2)
+	for _, element := range path {
+		words = append(words, element.tag)
+		if element.name != "" {
+			words = append(words, quoteWord(element.name))
+		}
+	}
+	relativeLines := FilterLines(lines, strings.Join(words, " "), true)
+	if len(relativeLines) == 0 {
+		return "<configuration></configuration>"
+	}
+	node := root
+	for _, element := range path {
+		child := &xmlNode{tag: element.tag, name: element.name}
+		node.children = append(node.children, child)
+		node = child
+	}
+	for _, line := range relativeLines {
+		node.add(splitWords(strings.TrimPrefix(line, "set")))
+	}
+	var output strings.Builder
+	root.write(&output)
+
+	return output.String()
+}
+
+// add adds the element of statement words in node.
+func (node *xmlNode) add(words []string) {
+	if len(words) == 0 {
+		return
+	}
+	tag := words[0]
+	if tags, ok := xmlValues[tag]; ok && len(words) == len(tags)+1 {
+		child := node.child(tag, "")
+		child.children = nil
+		for i, valueTag := range tags {
+			child.children = append(child.children, &xmlNode{tag: valueTag, value: words[i+1], hasValue: true})
+		}
+
+		return
+	}
+	switch {
+	case xmlContainers[tag]:
+		node.child(tag, "").add(words[1:])
+	case len(words) == 1:
+		node.child(tag, "")
+	case len(words) == 2 && !xmlEntries[tag]:
+		for _, child := range node.children {
+			if child.tag == tag && child.hasValue && child.value == words[1] {
+				return
+			}
+		}
+		node.children = append(node.children, &xmlNode{tag: tag, value: words[1], hasValue: true})
+	default:
+		node.child(tag, words[1]).add(words[2:])
+	}
+}
+
+// child returns the child element of node with tag and name (added if not exists).
+func (node *xmlNode) child(tag, name string) *xmlNode {
+	for _, child := range node.children {
+		if child.tag == tag && child.name == name && !child.hasValue {
+			return child
+		}
+	}
+	child := &xmlNode{tag: tag, name: name}
+	node.children = append(node.children, child)
+
+	return child
+}
+
+func (node *xmlNode) write(output *strings.Builder) {
+	output.WriteString("<" + node.tag + ">")
+	if node.name != "" {
+		output.WriteString("<name>" + Escape(node.name) + "</name>")
+	}
+	if node.hasValue {
+		output.WriteString(Escape(node.value))
+	}
+	for _, child := range node.children {
+		child.write(output)
+	}
+	output.WriteString("</" + node.tag + ">")
+}
+
+// quoteWord returns the word of a `set` line with double quotes if it contains a space.
+func quoteWord(word string) string {
+	if strings.Contains(word, " ") {
+		return "\"" + word + "\""
+	}
+
+	return word
+}
+
+// splitWords splits a `set` line in words, the words in double quotes are kept together
+// (without the quotes).
+func splitWords(line string) []string {
+	words := make([]string, 0)
+	var word strings.Builder
+	inQuotes := false
+	escaped := false
+	quoted := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && inQuotes:
+			escaped = true
+
+			continue
+		case r == '"':
+			inQuotes = !inQuotes
+			quoted = true
+
+			continue
+		case r == ' ' && !inQuotes:
+			if word.Len() > 0 || quoted {
+				words = append(words, word.String())
+				word.Reset()
+				quoted = false
+			}
+
+			continue
+		}
+		word.WriteRune(r)
+	}
+	if word.Len() > 0 || quoted {
+		words = append(words, word.String())
+	}
+
+	return words
+}
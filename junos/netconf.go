@@ -1,40 +1,81 @@
 package junos
 
 import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/xml"
 	"errors"
 	"fmt"
 	"log"
+	"net"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/jeremmfr/go-netconf/netconf"
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
 )
 
 const (
 	errorSeverity string = "error"
 
 	rpcCommand         = "<command format=\"text\">%s</command>"
+	rpcCommandFormat   = "<command format=\"%s\">%s</command>"
 	rpcConfigStringSet = "<load-configuration action=\"set\" format=\"text\">" +
 		"<configuration-set>%s</configuration-set></load-configuration>"
 	rpcSystemInfo      = "<get-system-information/>"
-	rpcCommit          = "<commit-configuration><log>%s</log></commit-configuration>"
-	rpcCandidateLock   = "<lock><target><candidate/></target></lock>"
-	rpcCandidateUnlock = "<unlock><target><candidate/></target></unlock>"
-	rpcClearCandidate  = "<delete-config><target><candidate/></target></delete-config>"
-	rpcClose           = "<close-session/>"
+	rpcCommit          = "<commit-configuration>%s<log>%s</log></commit-configuration>"
+	rpcCommitCheck     = "<commit-configuration><check/></commit-configuration>"
+	rpcCommitConfirmed = "<commit-configuration><confirmed/><confirm-timeout>%d</confirm-timeout>" +
+		"%s<log>%s</log></commit-configuration>"
+	rpcSynchronize      = "<synchronize/>"
+	rpcForceSynchronize = "<synchronize/><force-synchronize/>"
+	rpcCandidateLock    = "<lock><target><candidate/></target></lock>"
+	rpcOpenPrivate      = "<open-configuration><private/></open-configuration>"
+	rpcCloseConfig      = "<close-configuration/>"
+	rpcCandidateUnlock  = "<unlock><target><candidate/></target></unlock>"
+	rpcClearCandidate   = "<delete-config><target><candidate/></target></delete-config>"
+	rpcClose            = "<close-session/>"
+
+	rpcGetCandidateConfigSet = "<get-configuration database=\"candidate\" format=\"set\"/>"
+	rpcGetCommittedConfigSet = "<get-configuration database=\"committed\" format=\"set\"/>"
+	rpcGetCommittedConfigXML = "<get-configuration database=\"committed\" format=\"xml\"/>"
+	rpcGetCompareRollback    = "<get-configuration compare=\"rollback\" rollback=\"0\" format=\"text\"/>"
 
 	rpcGetInterfaceInformationTerse = `<get-interface-information><terse/></get-interface-information>`
+	rpcGetRouteEngineInformation    = `<get-route-engine-information/>`
 )
 
 // NetconfObject : store Junos device info and session.
 type NetconfObject struct {
-	Session           *netconf.Session
+	locked            bool
+	deferred          bool
+	closed            bool
+	rpcTimeout        time.Duration
+	ctx               context.Context
+	pending           []string
+	transport         netconfTransport
 	SystemInformation sysInfo `xml:"system-information"`
 }
 
+// netconfTransport is a netconf session with device: rpc (lock, load, commit...) are sent with Exec
+// and Close closes the connection.
+// It is implemented by *netconf.Session and by the in-memory fake device for unit tests.
+// There is no Lock/Unlock/Commit method: every operation is a raw rpc built by NetconfObject,
+// so they all go through execContext (rpc timeout, cancellation, transport errors)
+// and the fake device receives the same rpc as a real device.
+type netconfTransport interface {
+	Exec(methods ...netconf.RPCMethod) (*netconf.RPCReply, error)
+	Close() error
+}
+
 type sysInfo struct {
 	HardwareModel string `xml:"hardware-model"`
 	OsName        string `xml:"os-name"`
@@ -48,13 +89,59 @@ type commandXMLConfig struct {
 	Config string `xml:",innerxml"`
 }
 
+var knownHostsMutex = &sync.Mutex{} // nolint: gochecknoglobals
+
+// jumpHostDialTimeout is the maximum duration to connect to the first jump host.
+var jumpHostDialTimeout = 30 * time.Second // nolint: gochecknoglobals
+
+// netconfJumpHost is a SSH server used as jump host (like OpenSSH ProxyJump) to reach device.
+type netconfJumpHost struct {
+	Address string
+	Auth    netconfAuthMethod
+}
+
+// transportJumpHosts closes the SSH connections to jump hosts with the netconf transport.
+type transportJumpHosts struct {
+	netconf.Transport
+	jumpClients []*ssh.Client
+}
+
+// tunnelConn is a connection tunnelled through jump hosts with the address of device as remote address
+// to verify its host key.
+type tunnelConn struct {
+	net.Conn
+	address hostAddr
+}
+
 type netconfAuthMethod struct {
-	Password       string
-	Username       string
-	PrivateKeyPEM  string
-	PrivateKeyFile string
-	Passphrase     string
-	Ciphers        []string
+	HostKeyTOFU         bool
+	FilePermission      int64
+	Password            string
+	Username            string
+	PrivateKeyPEM       string
+	PrivateKeyFile      string
+	Passphrase          string
+	CertificatePEM      string
+	CertificateFile     string
+	KnownHostsFile      string
+	Ciphers             []string
+	HostKeyFingerprints []string
+}
+
+type configurationSetReply struct {
+	Config string `xml:",chardata"`
+}
+
+type configurationCompareReply struct {
+	XMLName xml.Name `xml:"configuration-information"`
+	Output  string   `xml:"configuration-output"`
+}
+
+type routeEngineInformationReply struct {
+	XMLName     xml.Name `xml:"route-engine-information"`
+	RouteEngine []struct {
+		Slot string `xml:"slot"`
+	} `xml:"route-engine"`
 }
 
 type commitResults struct {
@@ -76,15 +163,128 @@ type getInterfaceTerseReply struct {
 // to run our commands against.
 // Authentication methods are defined using the netconfAuthMethod struct, and are as follows:
 // username and password, SSH private key (with or without passphrase).
-func netconfNewSession(host string, auth *netconfAuthMethod) (*NetconfObject, error) {
-	clientConfig, err := genSSHClientConfig(auth)
+// The connection is tunnelled through the jump hosts if there are.
+func netconfNewSession(host string, auth *netconfAuthMethod, jumpHosts []netconfJumpHost) (*NetconfObject, error) {
+	clientConfig, err := genSSHClientConfig(auth, host)
 	if err != nil {
 		return nil, err
 	}
+	if len(jumpHosts) > 0 {
+		return netconfNewSessionWithJumpHosts(host, clientConfig, jumpHosts)
+	}
 
 	return netconfNewSessionWithConfig(host, clientConfig)
 }
 
+// netconfNewSessionWithJumpHosts establishes a new connection to a NetconfObject device through
+// a chain of jump hosts.
+func netconfNewSessionWithJumpHosts(
+	host string, clientConfig *ssh.ClientConfig, jumpHosts []netconfJumpHost,
+) (*NetconfObject, error) {
+	jumpClients := make([]*ssh.Client, 0, len(jumpHosts))
+	closeJumpClients := func() {
+		for i := len(jumpClients) - 1; i >= 0; i-- {
+			jumpClients[i].Close()
+		}
+	}
+	for i := range jumpHosts {
+		jumpHost := jumpHosts[i]
+		jumpConfig, err := genSSHClientConfig(&jumpHost.Auth, jumpHost.Address)
+		if err != nil {
+			closeJumpClients()
+
+			return nil, fmt.Errorf("failed to prepare connection to jump host %s : %w", jumpHost.Address, err)
+		}
+		if i == 0 {
+			client, err := dialJumpHost(jumpHost.Address, jumpConfig)
+			if err != nil {
+				return nil, fmt.Errorf("error connecting to jump host %s - %w", jumpHost.Address, err)
+			}
+			jumpClients = append(jumpClients, client)
+
+			continue
+		}
+		conn, err := jumpClients[i-1].Dial("tcp", jumpHost.Address)
+		if err != nil {
+			closeJumpClients()
+
+			return nil, fmt.Errorf("error connecting to jump host %s through %s - %w",
+				jumpHost.Address, jumpHosts[i-1].Address, err)
+		}
+		c, chans, reqs, err := ssh.NewClientConn(conn, jumpHost.Address, jumpConfig)
+		if err != nil {
+			conn.Close()
+			closeJumpClients()
+
+			return nil, fmt.Errorf("error connecting to jump host %s through %s - %w",
+				jumpHost.Address, jumpHosts[i-1].Address, err)
+		}
+		jumpClients = append(jumpClients, ssh.NewClient(c, chans, reqs))
+	}
+	conn, err := jumpClients[len(jumpClients)-1].Dial("tcp", host)
+	if err != nil {
+		closeJumpClients()
+
+		return nil, fmt.Errorf("error connecting to %s through %s - %w",
+			host, jumpHosts[len(jumpHosts)-1].Address, err)
+	}
+	s, err := netconf.NewSSHSession(&tunnelConn{Conn: conn, address: hostAddr(host)}, clientConfig)
+	if err != nil {
+		conn.Close()
+		closeJumpClients()
+
+		return nil, fmt.Errorf("error connecting to %s through %s - %w",
+			host, jumpHosts[len(jumpHosts)-1].Address, err)
+	}
+	s.Transport = &transportJumpHosts{
+		Transport:   s.Transport,
+		jumpClients: jumpClients,
+	}
+
+	return newSessionFromNetconf(s)
+}
+
+// dialJumpHost connects to the first jump host, the TCP connection and the SSH handshake
+// are limited by jumpHostDialTimeout so an unreachable jump host doesn't block until the TCP timeout of OS.
+func dialJumpHost(address string, jumpConfig *ssh.ClientConfig) (*ssh.Client, error) {
+	conn, err := net.DialTimeout("tcp", address, jumpHostDialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.SetDeadline(time.Now().Add(jumpHostDialTimeout)); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+	c, chans, reqs, err := ssh.NewClientConn(conn, address, jumpConfig)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		c.Close()
+
+		return nil, err
+	}
+
+	return ssh.NewClient(c, chans, reqs), nil
+}
+
+// Close closes the netconf transport then the connections to jump hosts.
+func (t *transportJumpHosts) Close() error {
+	err := t.Transport.Close()
+	for i := len(t.jumpClients) - 1; i >= 0; i-- {
+		t.jumpClients[i].Close()
+	}
+
+	return err
+}
+
+func (c *tunnelConn) RemoteAddr() net.Addr {
+	return c.address
+}
+
 // netconfNewSessionWithConfig establishes a new connection to a NetconfObject device that we will use
 // to run our commands against.
 func netconfNewSessionWithConfig(host string, clientConfig *ssh.ClientConfig) (*NetconfObject, error) {
@@ -96,22 +296,28 @@ func netconfNewSessionWithConfig(host string, clientConfig *ssh.ClientConfig) (*
 	return newSessionFromNetconf(s)
 }
 
-// newSessionFromNetconf uses an existing netconf.Session to run our commands against.
-func newSessionFromNetconf(s *netconf.Session) (*NetconfObject, error) {
-	n := &NetconfObject{
-		Session: s,
-	}
-
-	return n, n.gatherFacts()
+// newSessionFromNetconf uses an existing netconf session to run our commands against.
+func newSessionFromNetconf(s netconfTransport) (*NetconfObject, error) {
+	return &NetconfObject{
+		transport: s,
+	}, nil
 }
 
 // genSSHClientConfig is a wrapper function based around the auth method defined
 // (user/password or private key) which returns the SSH client configuration used to
 // connect.
-func genSSHClientConfig(auth *netconfAuthMethod) (*ssh.ClientConfig, error) {
+func genSSHClientConfig(auth *netconfAuthMethod, address string) (*ssh.ClientConfig, error) {
 	configs := make([]*ssh.ClientConfig, 0)
 	configs = append(configs, &ssh.ClientConfig{})
 
+	// certificate method
+	if len(auth.CertificatePEM) > 0 || len(auth.CertificateFile) > 0 {
+		config, err := sshConfigCertificate(auth)
+		if err != nil {
+			return config, fmt.Errorf("failed to create new SSHConfig with certificate : %w", err)
+		}
+		configs = append(configs, config)
+	}
 	// keys method
 	switch {
 	case len(auth.PrivateKeyPEM) > 0:
@@ -137,13 +343,24 @@ func genSSHClientConfig(auth *netconfAuthMethod) (*ssh.ClientConfig, error) {
 	if len(auth.Password) > 0 {
 		config := netconf.SSHConfigPassword(auth.Username, auth.Password)
 		configs = append(configs, config)
+		configs = append(configs, &ssh.ClientConfig{
+			User: auth.Username,
+			Auth: []ssh.AuthMethod{
+				ssh.KeyboardInteractive(sshKeyboardInteractivePassword(auth.Password)),
+			},
+		})
 	}
 	if len(configs) == 1 {
 		return configs[0], errors.New("no credentials/keys available")
 	}
+	hostKeyCallback, err := genSSHHostKeyCallback(auth)
+	if err != nil {
+		return configs[0], err
+	}
 	configs[0] = configs[1]
 	configs[0].Ciphers = auth.Ciphers
-	configs[0].HostKeyCallback = ssh.InsecureIgnoreHostKey()
+	configs[0].HostKeyCallback = hostKeyCallback
+	configs[0].HostKeyAlgorithms = knownHostsKeyAlgorithms(auth, address)
 	for _, v := range configs[2:] {
 		configs[0].Auth = append(configs[0].Auth, v.Auth...)
 	}
@@ -151,6 +368,275 @@ func genSSHClientConfig(auth *netconfAuthMethod) (*ssh.ClientConfig, error) {
 	return configs[0], nil
 }
 
+// sshConfigCertificate returns a SSH client configuration with a signer of OpenSSH certificate
+// and the private key from sshkey_pem, sshkeyfile or SSH agent.
+func sshConfigCertificate(auth *netconfAuthMethod) (*ssh.ClientConfig, error) {
+	certBytes := []byte(auth.CertificatePEM)
+	if len(certBytes) == 0 {
+		var err error
+		certBytes, err = os.ReadFile(auth.CertificateFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read file `%s` : %w", auth.CertificateFile, err)
+		}
+	}
+	pubKey, _, _, _, err := ssh.ParseAuthorizedKey(certBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate : %w", err)
+	}
+	cert, ok := pubKey.(*ssh.Certificate)
+	if !ok {
+		return nil, errors.New("public key isn't a certificate")
+	}
+	var signer ssh.Signer
+	switch {
+	case len(auth.PrivateKeyPEM) > 0:
+		signer, err = sshParsePrivateKey([]byte(auth.PrivateKeyPEM), auth.Passphrase)
+		if err != nil {
+			return nil, err
+		}
+	case len(auth.PrivateKeyFile) > 0:
+		keyBytes, err := os.ReadFile(auth.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read file `%s` : %w", auth.PrivateKeyFile, err)
+		}
+		signer, err = sshParsePrivateKey(keyBytes, auth.Passphrase)
+		if err != nil {
+			return nil, err
+		}
+	case os.Getenv("SSH_AUTH_SOCK") != "":
+		conn, err := net.Dial("unix", os.Getenv("SSH_AUTH_SOCK"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to communicate with SSH agent : %w", err)
+		}
+		signers, err := agent.NewClient(conn).Signers()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read keys from SSH agent : %w", err)
+		}
+		for _, v := range signers {
+			if bytes.Equal(v.PublicKey().Marshal(), cert.Key.Marshal()) {
+				signer = v
+
+				break
+			}
+		}
+		if signer == nil {
+			return nil, errors.New("no key in SSH agent matches the certificate")
+		}
+	default:
+		return nil, errors.New("no private key available for the certificate")
+	}
+	if !bytes.Equal(signer.PublicKey().Marshal(), cert.Key.Marshal()) {
+		return nil, errors.New("private key doesn't match the certificate")
+	}
+	certSigner, err := ssh.NewCertSigner(cert, signer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate signer : %w", err)
+	}
+
+	return &ssh.ClientConfig{
+		User: auth.Username,
+		Auth: []ssh.AuthMethod{
+			ssh.PublicKeys(certSigner),
+		},
+	}, nil
+}
+
+// sshParsePrivateKey parses a private key in PEM format, with passphrase if the key is protected.
+func sshParsePrivateKey(key []byte, passphrase string) (ssh.Signer, error) {
+	signer, err := ssh.ParsePrivateKey(key)
+	if err != nil {
+		var passphraseMissingError *ssh.PassphraseMissingError
+		if errors.As(err, &passphraseMissingError) && passphrase != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(passphrase))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse private key with passphrase : %w", err)
+			}
+
+			return signer, nil
+		}
+
+		return nil, fmt.Errorf("failed to parse private key : %w", err)
+	}
+
+	return signer, nil
+}
+
+// sshKeyboardInteractivePassword answers the password prompts of keyboard-interactive
+// authentication (TACACS+, RADIUS...) with password.
+func sshKeyboardInteractivePassword(password string) ssh.KeyboardInteractiveChallenge {
+	return func(user, instruction string, questions []string, echos []bool) ([]string, error) {
+		answers := make([]string, len(questions))
+		for i, question := range questions {
+			if strings.Contains(strings.ToLower(question), "password") {
+				answers[i] = password
+			}
+		}
+
+		return answers, nil
+	}
+}
+
+// genSSHHostKeyCallback returns the callback used to verify the host key of the device.
+// Keys are accepted if they match one of the pinned fingerprints or the known_hosts file.
+// With trust on first use, the key of an unknown host is recorded in the known_hosts file.
+// Without fingerprints and known_hosts file, host key isn't verified.
+func genSSHHostKeyCallback(auth *netconfAuthMethod) (ssh.HostKeyCallback, error) {
+	if len(auth.HostKeyFingerprints) == 0 && auth.KnownHostsFile == "" {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+	if auth.KnownHostsFile != "" && !auth.HostKeyTOFU {
+		if _, err := os.Stat(auth.KnownHostsFile); err != nil {
+			return nil, fmt.Errorf("failed to read known_hosts file `%s` : %w", auth.KnownHostsFile, err)
+		}
+	}
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		fingerprint := ssh.FingerprintSHA256(key)
+		for _, v := range auth.HostKeyFingerprints {
+			if strings.TrimPrefix(v, "SHA256:") == strings.TrimPrefix(fingerprint, "SHA256:") {
+				return nil
+			}
+		}
+		if auth.KnownHostsFile == "" {
+			return fmt.Errorf("host key verification failed for %s : "+
+				"fingerprint %s doesn't match any of ssh_host_key_fingerprints", hostname, fingerprint)
+		}
+		knownHostsMutex.Lock()
+		defer knownHostsMutex.Unlock()
+		if auth.HostKeyTOFU {
+			if _, err := os.Stat(auth.KnownHostsFile); os.IsNotExist(err) {
+				if err := writeKnownHostsLine(auth, hostname, remote, key); err != nil {
+					return err
+				}
+				log.Printf("[WARN] host key %s for %s recorded in %s (trust on first use)",
+					fingerprint, hostname, auth.KnownHostsFile)
+
+				return nil
+			}
+		}
+		callback, err := knownhosts.New(auth.KnownHostsFile)
+		if err != nil {
+			return fmt.Errorf("failed to read known_hosts file `%s` : %w", auth.KnownHostsFile, err)
+		}
+		err = callback(hostname, remote, key)
+		if err == nil {
+			return nil
+		}
+		var keyErr *knownhosts.KeyError
+		if errors.As(err, &keyErr) {
+			if len(keyErr.Want) > 0 {
+				return fmt.Errorf("host key verification failed for %s : "+
+					"fingerprint %s doesn't match the key recorded in %s:%d, "+
+					"the device has been replaced/reinstalled or the connection is intercepted",
+					hostname, fingerprint, keyErr.Want[0].Filename, keyErr.Want[0].Line)
+			}
+			if auth.HostKeyTOFU {
+				if err := writeKnownHostsLine(auth, hostname, remote, key); err != nil {
+					return err
+				}
+				log.Printf("[WARN] host key %s for %s recorded in %s (trust on first use)",
+					fingerprint, hostname, auth.KnownHostsFile)
+
+				return nil
+			}
+
+			return fmt.Errorf("host key verification failed for %s : "+
+				"host is unknown in %s (fingerprint %s)", hostname, auth.KnownHostsFile, fingerprint)
+		}
+
+		return fmt.Errorf("host key verification failed for %s : %w", hostname, err)
+	}, nil
+}
+
+// knownHostsKeyAlgorithms returns the host key algorithms of the keys recorded in known_hosts file
+// for address, so that the device offers a host key of these types instead of its preferred type.
+// Returns nil (the default algorithms) without known_hosts file, with pinned fingerprints
+// (their key types are unknown) or when the host is unknown.
+func knownHostsKeyAlgorithms(auth *netconfAuthMethod, address string) []string {
+	if auth.KnownHostsFile == "" || len(auth.HostKeyFingerprints) > 0 {
+		return nil
+	}
+	knownHostsMutex.Lock()
+	defer knownHostsMutex.Unlock()
+	callback, err := knownhosts.New(auth.KnownHostsFile)
+	if err != nil {
+		return nil
+	}
+	// a random key never matches, so the error lists the keys recorded for address
+	probeKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil
+	}
+	probe, err := ssh.NewPublicKey(probeKey)
+	if err != nil {
+		return nil
+	}
+	var keyErr *knownhosts.KeyError
+	if !errors.As(callback(address, hostAddr(address), probe), &keyErr) {
+		return nil
+	}
+	knownTypes := make(map[string]bool)
+	for _, known := range keyErr.Want {
+		knownTypes[known.Key.Type()] = true
+	}
+	algorithms := make([]string, 0)
+	for _, algorithm := range []string{
+		ssh.KeyAlgoED25519,
+		ssh.KeyAlgoECDSA521, ssh.KeyAlgoECDSA384, ssh.KeyAlgoECDSA256,
+		ssh.SigAlgoRSASHA2512, ssh.SigAlgoRSASHA2256, ssh.KeyAlgoRSA,
+		ssh.KeyAlgoDSA,
+	} {
+		keyType := algorithm
+		if algorithm == ssh.SigAlgoRSASHA2512 || algorithm == ssh.SigAlgoRSASHA2256 {
+			keyType = ssh.KeyAlgoRSA
+		}
+		if knownTypes[keyType] {
+			algorithms = append(algorithms, algorithm)
+		}
+	}
+	if len(algorithms) == 0 {
+		return nil
+	}
+
+	return algorithms
+}
+
+// hostAddr is the address of device (host:port) as remote address to verify its keys in known_hosts file.
+type hostAddr string
+
+func (a hostAddr) Network() string {
+	return "tcp"
+}
+
+func (a hostAddr) String() string {
+	return string(a)
+}
+
+// writeKnownHostsLine appends the host key of device in known_hosts file (create it if needed).
+func writeKnownHostsLine(auth *netconfAuthMethod, hostname string, remote net.Addr, key ssh.PublicKey) error {
+	dirFile := filepath.Dir(auth.KnownHostsFile)
+	if _, err := os.Stat(dirFile); err != nil {
+		if err := os.MkdirAll(dirFile, os.FileMode(directoryPermission)); err != nil {
+			return fmt.Errorf("failed to create parent directory of `%s` : %w", auth.KnownHostsFile, err)
+		}
+	}
+	f, err := os.OpenFile(auth.KnownHostsFile,
+		os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(auth.FilePermission))
+	if err != nil {
+		return fmt.Errorf("failed to openfile `%s` : %w", auth.KnownHostsFile, err)
+	}
+	defer f.Close()
+	addresses := []string{knownhosts.Normalize(hostname)}
+	if remote != nil && knownhosts.Normalize(remote.String()) != addresses[0] {
+		addresses = append(addresses, knownhosts.Normalize(remote.String()))
+	}
+	if _, err := f.WriteString(knownhosts.Line(addresses, key) + "\n"); err != nil {
+		return fmt.Errorf("failed to write in file `%s` : %w", auth.KnownHostsFile, err)
+	}
+
+	return nil
+}
+
 func defaultSSHCiphers() schema.SchemaDefaultFunc {
 	return func() (interface{}, error) {
 		return []interface{}{
@@ -166,9 +652,8 @@ func (j *NetconfObject) gatherFacts() error {
 	if j == nil {
 		return errors.New("attempt to call GatherFacts on nil NetconfObject object")
 	}
-	s := j.Session
 	// Get info for get-system-information and populate SystemInformation Struct
-	val, err := s.Exec(netconf.RawMethod(rpcSystemInfo))
+	val, err := j.exec(rpcSystemInfo)
 	if err != nil {
 		return fmt.Errorf("failed to netconf get-system-information : %w", err)
 	}
@@ -192,7 +677,7 @@ func (j *NetconfObject) gatherFacts() error {
 // netconfCommand (show, execute) on Junos device.
 func (j *NetconfObject) netconfCommand(cmd string) (string, error) {
 	command := fmt.Sprintf(rpcCommand, cmd)
-	reply, err := j.Session.Exec(netconf.RawMethod(command))
+	reply, err := j.exec(command)
 	if err != nil {
 		return "", fmt.Errorf("failed to netconf command exec : %w", err)
 	}
@@ -213,7 +698,7 @@ func (j *NetconfObject) netconfCommand(cmd string) (string, error) {
 }
 
 func (j *NetconfObject) netconfCommandXML(cmd string) (string, error) {
-	reply, err := j.Session.Exec(netconf.RawMethod(cmd))
+	reply, err := j.exec(cmd)
 	if err != nil {
 		return "", fmt.Errorf("failed to netconf xml command exec : %w", err)
 	}
@@ -228,7 +713,7 @@ func (j *NetconfObject) netconfCommandXML(cmd string) (string, error) {
 
 func (j *NetconfObject) netconfConfigSet(cmd []string) (string, error) {
 	command := fmt.Sprintf(rpcConfigStringSet, strings.Join(cmd, "\n"))
-	reply, err := j.Session.Exec(netconf.RawMethod(command))
+	reply, err := j.exec(command)
 	if err != nil {
 		return "", fmt.Errorf("failed to netconf set/delete command exec : %w", err)
 	}
@@ -246,21 +731,50 @@ func (j *NetconfObject) netconfConfigSet(cmd []string) (string, error) {
 }
 
 // netConfConfigLock locks the candidate configuration.
-func (j *NetconfObject) netconfConfigLock() bool {
-	reply, err := j.Session.Exec(netconf.RawMethod(rpcCandidateLock))
+func (j *NetconfObject) netconfConfigLock() error {
+	return j.netconfConfigOpen(rpcCandidateLock)
+}
+
+// netconfConfigOpenPrivate opens a private candidate configuration.
+func (j *NetconfObject) netconfConfigOpenPrivate() error {
+	return j.netconfConfigOpen(rpcOpenPrivate)
+}
+
+func (j *NetconfObject) netconfConfigOpen(rpc string) error {
+	reply, err := j.exec(rpc)
+	if err != nil {
+		return err
+	}
+	if reply.Errors != nil {
+		for _, m := range reply.Errors {
+			return errors.New(m.Error())
+		}
+	}
+
+	return nil
+}
+
+// netconfConfigCloseConfig closes the private candidate configuration and discards its uncommitted changes.
+func (j *NetconfObject) netconfConfigCloseConfig() []error {
+	reply, err := j.execCleanup(rpcCloseConfig)
 	if err != nil {
-		return false
+		return []error{fmt.Errorf("failed to netconf close configuration : %w", err)}
 	}
 	if reply.Errors != nil {
-		return false
+		errs := make([]error, 0)
+		for _, m := range reply.Errors {
+			errs = append(errs, errors.New("close configuration: "+m.Message))
+		}
+
+		return errs
 	}
 
-	return true
+	return []error{}
 }
 
 // Unlock unlocks the candidate configuration.
 func (j *NetconfObject) netconfConfigUnlock() []error {
-	reply, err := j.Session.Exec(netconf.RawMethod(rpcCandidateUnlock))
+	reply, err := j.execCleanup(rpcCandidateUnlock)
 	if err != nil {
 		return []error{fmt.Errorf("failed to netconf config unlock : %w", err)}
 	}
@@ -277,7 +791,7 @@ func (j *NetconfObject) netconfConfigUnlock() []error {
 }
 
 func (j *NetconfObject) netconfConfigClear() []error {
-	reply, err := j.Session.Exec(netconf.RawMethod(rpcClearCandidate))
+	reply, err := j.execCleanup(rpcClearCandidate)
 	if err != nil {
 		return []error{fmt.Errorf("failed to netconf config clear : %w", err)}
 	}
@@ -294,8 +808,34 @@ func (j *NetconfObject) netconfConfigClear() []error {
 }
 
 // netconfCommit commits the configuration.
-func (j *NetconfObject) netconfCommit(logMessage string) (_warn []error, _err error) {
-	reply, err := j.Session.Exec(netconf.RawMethod(fmt.Sprintf(rpcCommit, logMessage)))
+// synchronize is empty or the elements to synchronize the commit on the other routing engine
+// (rpcSynchronize or rpcForceSynchronize).
+func (j *NetconfObject) netconfCommit(logMessage, synchronize string) (_warn []error, _err error) {
+	return j.netconfCommitRPC(fmt.Sprintf(rpcCommit, synchronize, escapeLogMessage(logMessage)))
+}
+
+// netconfCommitConfirmed commits the configuration with an automatic rollback
+// if not confirmed before timeout (in minutes).
+func (j *NetconfObject) netconfCommitConfirmed(
+	logMessage, synchronize string, timeout int) (_warn []error, _err error) {
+	return j.netconfCommitRPC(fmt.Sprintf(rpcCommitConfirmed, timeout, synchronize, escapeLogMessage(logMessage)))
+}
+
+// escapeLogMessage escapes the log message of commit for the xml rpc.
+func escapeLogMessage(logMessage string) string {
+	var escaped strings.Builder
+	_ = xml.EscapeText(&escaped, []byte(logMessage))
+
+	return escaped.String()
+}
+
+// netconfCommitCheck checks the candidate configuration without commit.
+func (j *NetconfObject) netconfCommitCheck() (_warn []error, _err error) {
+	return j.netconfCommitRPC(rpcCommitCheck)
+}
+
+func (j *NetconfObject) netconfCommitRPC(rpc string) (_warn []error, _err error) {
+	reply, err := j.exec(rpc)
 	if err != nil {
 		return []error{}, fmt.Errorf("failed to netconf commit : %w", err)
 	}
@@ -304,7 +844,9 @@ func (j *NetconfObject) netconfCommit(logMessage string) (_warn []error, _err er
 		warnings := make([]error, 0)
 		for _, m := range reply.Errors {
 			if m.Severity == errorSeverity {
-				return warnings, errors.New(m.Error())
+				rpcErr := m
+
+				return warnings, &rpcErr
 			}
 			warnings = append(warnings, errors.New(m.Error()))
 		}
@@ -323,7 +865,9 @@ func (j *NetconfObject) netconfCommit(logMessage string) (_warn []error, _err er
 			warnings := make([]error, 0)
 			for _, m := range errs.Errors {
 				if m.Severity == errorSeverity {
-					return []error{}, errors.New(m.Error())
+					rpcErr := m
+
+					return []error{}, &rpcErr
 				}
 				warnings = append(warnings, errors.New(m.Error()))
 			}
@@ -335,10 +879,80 @@ func (j *NetconfObject) netconfCommit(logMessage string) (_warn []error, _err er
 	return []error{}, nil
 }
 
+// netconfCandidateSetLines reads the candidate configuration in `set` format.
+func (j *NetconfObject) netconfCandidateSetLines() ([]string, error) {
+	return j.netconfConfigSetLines(rpcGetCandidateConfigSet)
+}
+
+// netconfCommittedSetLines reads the committed configuration in `set` format.
+func (j *NetconfObject) netconfCommittedSetLines() ([]string, error) {
+	return j.netconfConfigSetLines(rpcGetCommittedConfigSet)
+}
+
+func (j *NetconfObject) netconfConfigSetLines(rpc string) ([]string, error) {
+	reply, err := j.exec(rpc)
+	if err != nil {
+		return []string{}, fmt.Errorf("failed to netconf get-configuration : %w", err)
+	}
+	if reply.Errors != nil {
+		for _, m := range reply.Errors {
+			return []string{}, errors.New(m.Error())
+		}
+	}
+	var config configurationSetReply
+	if err := xml.Unmarshal([]byte(reply.Data), &config); err != nil {
+		return []string{}, fmt.Errorf("failed to xml unmarshal reply : %w", err)
+	}
+
+	return splitSetLines(config.Config), nil
+}
+
+// netconfCompareRollback reads the differences between the candidate configuration and
+// the committed configuration (`show | compare`).
+func (j *NetconfObject) netconfCompareRollback() (string, error) {
+	reply, err := j.exec(rpcGetCompareRollback)
+	if err != nil {
+		return "", fmt.Errorf("failed to netconf get-configuration compare : %w", err)
+	}
+	if reply.Errors != nil {
+		for _, m := range reply.Errors {
+			return "", errors.New(m.Error())
+		}
+	}
+	if strings.TrimSpace(reply.Data) == "" {
+		return "", nil
+	}
+	var compare configurationCompareReply
+	if err := xml.Unmarshal([]byte(reply.Data), &compare); err != nil {
+		return "", fmt.Errorf("failed to xml unmarshal reply : %w", err)
+	}
+
+	return strings.Trim(compare.Output, "\n"), nil
+}
+
+// netconfRouteEngines returns the number of routing engines on device.
+func (j *NetconfObject) netconfRouteEngines() (int, error) {
+	reply, err := j.exec(rpcGetRouteEngineInformation)
+	if err != nil {
+		return 0, fmt.Errorf("failed to netconf get-route-engine-information : %w", err)
+	}
+	if reply.Errors != nil {
+		for _, m := range reply.Errors {
+			return 0, errors.New(m.Error())
+		}
+	}
+	var routeEngines routeEngineInformationReply
+	if err := xml.Unmarshal([]byte(reply.Data), &routeEngines); err != nil {
+		return 0, fmt.Errorf("failed to xml unmarshal reply : %w", err)
+	}
+
+	return len(routeEngines.RouteEngine), nil
+}
+
 // Close disconnects our session to the device.
 func (j *NetconfObject) close(sleepClosed int) error {
-	_, err := j.Session.Exec(netconf.RawMethod(rpcClose))
-	j.Session.Transport.Close()
+	_, err := j.execCleanup(rpcClose)
+	j.transport.Close()
 	if err != nil {
 		sleep(sleepClosed)
 
@@ -0,0 +1,112 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jeremmfr/go-netconf/netconf"
+)
+
+// rpcCancelGrace is the duration to wait the reply of a rpc in progress when the context is canceled
+// before closing the transport, to keep the session usable to clean up the candidate configuration.
+const rpcCancelGrace = 10 * time.Second
+
+var errRPCTimeout = errors.New("no reply before rpc timeout, netconf session closed") // nolint: gochecknoglobals
+
+// transportError is an error of the connection with device (connection lost, no reply)
+// and not an error returned by device.
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transportError) Unwrap() error {
+	return e.err
+}
+
+// isTransportError returns true if the error is a transport error and so the operation
+// can be retried with a new session.
+func isTransportError(err error) bool {
+	var errTransport *transportError
+
+	return errors.As(err, &errTransport)
+}
+
+type execResult struct {
+	reply *netconf.RPCReply
+	err   error
+}
+
+// exec sends a rpc and waits the reply until the rpc timeout or the cancellation of
+// the context of session.
+func (j *NetconfObject) exec(rpc string) (*netconf.RPCReply, error) {
+	ctx := j.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return j.execContext(ctx, rpc)
+}
+
+// execCleanup sends a rpc to clean up the session (clear candidate, unlock, close),
+// it ignores the cancellation of the context of session but not the rpc timeout.
+func (j *NetconfObject) execCleanup(rpc string) (*netconf.RPCReply, error) {
+	return j.execContext(context.Background(), rpc)
+}
+
+func (j *NetconfObject) execContext(ctx context.Context, rpc string) (*netconf.RPCReply, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("rpc not sent : %w", err)
+	}
+	done := make(chan execResult, 1)
+	go func() {
+		reply, err := j.transport.Exec(netconf.RawMethod(rpc))
+		done <- execResult{reply: reply, err: err}
+	}()
+	var timeout <-chan time.Time
+	if j.rpcTimeout > 0 {
+		timer := time.NewTimer(j.rpcTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case res := <-done:
+		return res.reply, res.classify()
+	case <-timeout:
+		j.closeTransport()
+
+		return nil, &transportError{err: fmt.Errorf("%w (%s)", errRPCTimeout, j.rpcTimeout)}
+	case <-ctx.Done():
+	}
+	// the rpc is already sent, wait the reply to keep the session in a known state,
+	// the next rpc (except to clean up) fails with the error of context.
+	grace := time.NewTimer(rpcCancelGrace)
+	defer grace.Stop()
+	select {
+	case res := <-done:
+		return res.reply, res.classify()
+	case <-timeout:
+	case <-grace.C:
+	}
+	j.closeTransport()
+
+	return nil, fmt.Errorf("no reply from device after cancellation, netconf session closed : %w", ctx.Err())
+}
+
+// classify returns the error of result as transportError if it's not an error returned by device.
+func (res execResult) classify() error {
+	if res.err == nil {
+		return nil
+	}
+	var rpcErr *netconf.RPCError
+	if errors.As(res.err, &rpcErr) {
+		return res.err
+	}
+
+	return &transportError{err: res.err}
+}
@@ -0,0 +1,68 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTLSStubSession(t *testing.T) (*Session, *tlsNetconfStub) {
+	t.Helper()
+	pki := newTLSStubPKI(t)
+	stub := startTLSNetconfStub(t, pki)
+	c := configProvider{
+		junosIP:             "127.0.0.1",
+		junosPort:           stub.port,
+		junosTransport:      transportTLS,
+		junosTLSCertPEM:     string(pki.clientCert),
+		junosTLSKeyPEM:      string(pki.clientKey),
+		junosTLSCABundlePEM: string(pki.caPEM),
+		junosFilePermission: "644",
+		junosCmdRPCTimeout:  1,
+		junosRetryAttempts:  2,
+	}
+	sess, diags := c.prepareSession(context.Background())
+	if diags.HasError() {
+		t.Fatalf("prepareSession: %v", diags)
+	}
+
+	return sess, stub
+}
+
+func TestNetconfExecRPCTimeout(t *testing.T) {
+	sess, _ := newTLSStubSession(t)
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer jnpr.closeTransport()
+	start := time.Now()
+	if _, err := jnpr.exec("<hang/>"); !errors.Is(err, errRPCTimeout) {
+		t.Fatalf("expected rpc timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("rpc timeout reached after %s", elapsed)
+	}
+}
+
+func TestNetconfExecCanceled(t *testing.T) {
+	sess, _ := newTLSStubSession(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	jnpr, err := sess.startNewSession(ctx)
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer jnpr.closeTransport()
+	cancel()
+	if _, err := jnpr.exec(rpcCommitCheck); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled error, got %v", err)
+	}
+	// clean up rpc still sent after cancellation
+	if errs := jnpr.netconfConfigClear(); len(errs) > 0 {
+		t.Fatalf("config clear after cancellation: %q", errs)
+	}
+	if err := sleepContext(ctx, time.Minute); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected canceled error for sleep, got %v", err)
+	}
+}
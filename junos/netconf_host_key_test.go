@@ -0,0 +1,167 @@
+package junos
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const hostKeyTestAddress = "192.0.2.1:830"
+
+func newHostKeyTestKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+	key, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("failed to create public key: %s", err)
+	}
+
+	return key
+}
+
+func writeHostKeyTestKnownHosts(t *testing.T, keys ...ssh.PublicKey) string {
+	t.Helper()
+	knownHostsFile := filepath.Join(t.TempDir(), "known_hosts")
+	lines := make([]string, 0, len(keys))
+	for _, key := range keys {
+		lines = append(lines, knownhosts.Line([]string{knownhosts.Normalize(hostKeyTestAddress)}, key))
+	}
+	if err := os.WriteFile(knownHostsFile, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+		t.Fatalf("failed to write known_hosts: %s", err)
+	}
+
+	return knownHostsFile
+}
+
+func checkHostKey(t *testing.T, auth *netconfAuthMethod, address string, key ssh.PublicKey) error {
+	t.Helper()
+	callback, err := genSSHHostKeyCallback(auth)
+	if err != nil {
+		t.Fatalf("genSSHHostKeyCallback: %s", err)
+	}
+
+	return callback(address, hostAddr(address), key)
+}
+
+func TestSSHHostKeyKnownHosts(t *testing.T) {
+	key := newHostKeyTestKey(t)
+	auth := &netconfAuthMethod{
+		KnownHostsFile: writeHostKeyTestKnownHosts(t, key),
+	}
+	if err := checkHostKey(t, auth, hostKeyTestAddress, key); err != nil {
+		t.Errorf("key recorded in known_hosts refused: %s", err)
+	}
+	err := checkHostKey(t, auth, hostKeyTestAddress, newHostKeyTestKey(t))
+	if err == nil {
+		t.Fatal("key different from known_hosts accepted")
+	}
+	if !strings.Contains(err.Error(), "doesn't match the key recorded") {
+		t.Errorf("unexpected error for a key mismatch: %s", err)
+	}
+	err = checkHostKey(t, auth, "192.0.2.2:830", key)
+	if err == nil || !strings.Contains(err.Error(), "host is unknown") {
+		t.Errorf("unexpected error for an unknown host: %v", err)
+	}
+}
+
+func TestSSHHostKeyKnownHostsMissing(t *testing.T) {
+	auth := &netconfAuthMethod{
+		KnownHostsFile: filepath.Join(t.TempDir(), "known_hosts"),
+	}
+	if _, err := genSSHHostKeyCallback(auth); err == nil {
+		t.Error("missing known_hosts file accepted without trust on first use")
+	}
+}
+
+func TestSSHHostKeyFingerprints(t *testing.T) {
+	key := newHostKeyTestKey(t)
+	for _, fingerprint := range []string{
+		ssh.FingerprintSHA256(key),
+		strings.TrimPrefix(ssh.FingerprintSHA256(key), "SHA256:"),
+	} {
+		auth := &netconfAuthMethod{
+			HostKeyFingerprints: []string{fingerprint},
+		}
+		if err := checkHostKey(t, auth, hostKeyTestAddress, key); err != nil {
+			t.Errorf("key with pinned fingerprint %q refused: %s", fingerprint, err)
+		}
+	}
+	auth := &netconfAuthMethod{
+		HostKeyFingerprints: []string{ssh.FingerprintSHA256(key)},
+	}
+	err := checkHostKey(t, auth, hostKeyTestAddress, newHostKeyTestKey(t))
+	if err == nil {
+		t.Fatal("key without pinned fingerprint accepted")
+	}
+	if !strings.Contains(err.Error(), "doesn't match any of ssh_host_key_fingerprints") {
+		t.Errorf("unexpected error for a fingerprint mismatch: %s", err)
+	}
+	// pinned fingerprints take precedence over a mismatch in known_hosts
+	auth.KnownHostsFile = writeHostKeyTestKnownHosts(t, newHostKeyTestKey(t))
+	if err := checkHostKey(t, auth, hostKeyTestAddress, key); err != nil {
+		t.Errorf("key with pinned fingerprint refused with known_hosts: %s", err)
+	}
+}
+
+func TestSSHHostKeyTOFU(t *testing.T) {
+	key := newHostKeyTestKey(t)
+	auth := &netconfAuthMethod{
+		HostKeyTOFU:    true,
+		FilePermission: 0o600,
+		KnownHostsFile: filepath.Join(t.TempDir(), "ssh", "known_hosts"),
+	}
+	if err := checkHostKey(t, auth, hostKeyTestAddress, key); err != nil {
+		t.Fatalf("first key refused with trust on first use: %s", err)
+	}
+	content, err := os.ReadFile(auth.KnownHostsFile)
+	if err != nil {
+		t.Fatalf("known_hosts not written: %s", err)
+	}
+	if line := knownhosts.Line([]string{knownhosts.Normalize(hostKeyTestAddress)}, key); !strings.Contains(
+		string(content), line) {
+		t.Errorf("known_hosts doesn't contain %q: %q", line, content)
+	}
+	if err := checkHostKey(t, auth, hostKeyTestAddress, key); err != nil {
+		t.Errorf("recorded key refused: %s", err)
+	}
+	if err := checkHostKey(t, auth, hostKeyTestAddress, newHostKeyTestKey(t)); err == nil {
+		t.Error("key different from the recorded key accepted with trust on first use")
+	}
+}
+
+func TestSSHHostKeyAlgorithms(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+	ecdsaPublicKey, err := ssh.NewPublicKey(&ecdsaKey.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to create public key: %s", err)
+	}
+	auth := &netconfAuthMethod{
+		KnownHostsFile: writeHostKeyTestKnownHosts(t, ecdsaPublicKey, newHostKeyTestKey(t)),
+	}
+	algorithms := knownHostsKeyAlgorithms(auth, hostKeyTestAddress)
+	expected := []string{ssh.KeyAlgoED25519, ssh.KeyAlgoECDSA256}
+	if strings.Join(algorithms, ",") != strings.Join(expected, ",") {
+		t.Errorf("unexpected host key algorithms %q, want %q", algorithms, expected)
+	}
+	if algorithms := knownHostsKeyAlgorithms(auth, "192.0.2.2:830"); algorithms != nil {
+		t.Errorf("host key algorithms %q for an unknown host", algorithms)
+	}
+	auth.HostKeyFingerprints = []string{ssh.FingerprintSHA256(ecdsaPublicKey)}
+	if algorithms := knownHostsKeyAlgorithms(auth, hostKeyTestAddress); algorithms != nil {
+		t.Errorf("host key algorithms %q with pinned fingerprints", algorithms)
+	}
+}
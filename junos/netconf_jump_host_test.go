@@ -0,0 +1,180 @@
+package junos
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startJumpHostStub starts a SSH stub which accepts to forward connections like a jump host.
+func startJumpHostStub(t *testing.T, username, password string) *sshNetconfStub {
+	t.Helper()
+
+	return startSSHNetconfStub(t, username, password, sshStubOptions{forwarding: true})
+}
+
+func TestNetconfJumpHosts(t *testing.T) {
+	device := startSSHNetconfStub(t, "terraform", "secret", sshStubOptions{})
+	jump1 := startJumpHostStub(t, "jump1", "secret1")
+	jump2 := startJumpHostStub(t, "jump2", "secret2")
+	c := configProvider{
+		junosIP:               "127.0.0.1",
+		junosPort:             device.port(),
+		junosUserName:         "terraform",
+		junosPassword:         "secret",
+		junosFilePermission:   "644",
+		junosSSHHostKeyFinger: []string{device.hostKeyFingerprint()},
+		junosSSHJumpHosts: []configSSHJumpHost{
+			{
+				host:          "127.0.0.1",
+				port:          jump1.port(),
+				username:      "jump1",
+				password:      "secret1",
+				hostKeyFinger: []string{jump1.hostKeyFingerprint()},
+			},
+			{
+				host:          "127.0.0.1",
+				port:          jump2.port(),
+				username:      "jump2",
+				password:      "secret2",
+				hostKeyFinger: []string{jump2.hostKeyFingerprint()},
+			},
+		},
+	}
+	sess, diags := c.prepareSession(context.Background())
+	if diags.HasError() {
+		t.Fatalf("prepareSession: %v", diags)
+	}
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession through jump hosts: %s", err)
+	}
+	if jnpr.SystemInformation.HardwareModel != "vsrx" {
+		t.Errorf("unexpected hardware model %q", jnpr.SystemInformation.HardwareModel)
+	}
+	sess.closeSession(jnpr)
+	jump2Address := "127.0.0.1:" + strconv.Itoa(jump2.port())
+	if forwarded := jump1.forwarded(); len(forwarded) != 1 || forwarded[0] != jump2Address {
+		t.Errorf("first jump host forwarded %q, want [%s]", forwarded, jump2Address)
+	}
+	deviceAddress := "127.0.0.1:" + strconv.Itoa(device.port())
+	if forwarded := jump2.forwarded(); len(forwarded) != 1 || forwarded[0] != deviceAddress {
+		t.Errorf("second jump host forwarded %q, want [%s]", forwarded, deviceAddress)
+	}
+}
+
+func TestNetconfJumpHostErrors(t *testing.T) {
+	jump := startJumpHostStub(t, "jump", "secret")
+	device := startSSHNetconfStub(t, "terraform", "secret", sshStubOptions{})
+	for name, testCase := range map[string]struct {
+		jumpHost configSSHJumpHost
+		err      string
+	}{
+		"host key": {
+			jumpHost: configSSHJumpHost{
+				host:          "127.0.0.1",
+				port:          jump.port(),
+				username:      "jump",
+				password:      "secret",
+				hostKeyFinger: []string{device.hostKeyFingerprint()},
+			},
+			err: "error connecting to jump host",
+		},
+		"authentication": {
+			jumpHost: configSSHJumpHost{
+				host:          "127.0.0.1",
+				port:          jump.port(),
+				username:      "jump",
+				password:      "bad",
+				hostKeyFinger: []string{jump.hostKeyFingerprint()},
+			},
+			err: "error connecting to jump host",
+		},
+	} {
+		c := configProvider{
+			junosIP:             "127.0.0.1",
+			junosPort:           device.port(),
+			junosUserName:       "terraform",
+			junosPassword:       "secret",
+			junosFilePermission: "644",
+			junosSSHJumpHosts:   []configSSHJumpHost{testCase.jumpHost},
+		}
+		sess, diags := c.prepareSession(context.Background())
+		if diags.HasError() {
+			t.Fatalf("prepareSession: %v", diags)
+		}
+		jnpr, err := sess.startNewSession(context.Background())
+		if err == nil {
+			sess.closeSession(jnpr)
+			t.Errorf("%s: session opened through jump host", name)
+
+			continue
+		}
+		if !strings.Contains(err.Error(), testCase.err) {
+			t.Errorf("%s: unexpected error %q", name, err)
+		}
+	}
+	if forwarded := jump.forwarded(); len(forwarded) != 0 {
+		t.Errorf("jump host forwarded %q without authentication", forwarded)
+	}
+}
+
+func TestNetconfJumpHostTimeout(t *testing.T) {
+	defaultTimeout := jumpHostDialTimeout
+	jumpHostDialTimeout = 500 * time.Millisecond
+	defer func() { jumpHostDialTimeout = defaultTimeout }()
+	// a listener which accepts the connection but never starts the SSH handshake
+	silent, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer silent.Close()
+	for name, address := range map[string]string{
+		"non-routable": "192.0.2.1:22",
+		"no handshake": silent.Addr().String(),
+	} {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		portNumber, err := strconv.Atoi(port)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := configProvider{
+			junosIP:             "127.0.0.1",
+			junosPort:           830,
+			junosUserName:       "terraform",
+			junosPassword:       "secret",
+			junosFilePermission: "644",
+			junosSSHJumpHosts: []configSSHJumpHost{{
+				host:          host,
+				port:          portNumber,
+				username:      "jump",
+				password:      "secret",
+				hostKeyFinger: []string{"SHA256:unknown"},
+			}},
+		}
+		sess, diags := c.prepareSession(context.Background())
+		if diags.HasError() {
+			t.Fatalf("prepareSession: %v", diags)
+		}
+		start := time.Now()
+		jnpr, err := sess.startNewSession(context.Background())
+		if err == nil {
+			sess.closeSession(jnpr)
+			t.Errorf("%s: session opened through jump host", name)
+
+			continue
+		}
+		if elapsed := time.Since(start); elapsed > 10*jumpHostDialTimeout {
+			t.Errorf("%s: connection to jump host failed after %s", name, elapsed)
+		}
+		if !strings.Contains(err.Error(), "error connecting to jump host") {
+			t.Errorf("%s: unexpected error %q", name, err)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package junos
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/jeremmfr/terraform-provider-junos/junos/internal/fakedevice"
+	"github.com/jeremmfr/terraform-provider-junos/junos/internal/netconfsim"
+	"golang.org/x/crypto/ssh"
+)
+
+// newSSHTestCA returns a signer for user certificates.
+func newSSHTestCA(t *testing.T) ssh.Signer {
+	t.Helper()
+	_, caKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate CA key: %s", err)
+	}
+	ca, err := ssh.NewSignerFromKey(caKey)
+	if err != nil {
+		t.Fatalf("failed to create CA signer: %s", err)
+	}
+
+	return ca
+}
+
+// newSSHTestCertificate returns a private key in PEM format and its user certificate
+// (in authorized_keys format) signed by ca for principal.
+func newSSHTestCertificate(t *testing.T, ca ssh.Signer, principal string) (string, string) {
+	t.Helper()
+	userKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate user key: %s", err)
+	}
+	userKeyDER, err := x509.MarshalECPrivateKey(userKey)
+	if err != nil {
+		t.Fatalf("failed to marshal user key: %s", err)
+	}
+	userPublicKey, err := ssh.NewPublicKey(&userKey.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to create user public key: %s", err)
+	}
+	cert := &ssh.Certificate{
+		Key:             userPublicKey,
+		CertType:        ssh.UserCert,
+		KeyId:           principal,
+		ValidPrincipals: []string{principal},
+		ValidBefore:     ssh.CertTimeInfinity,
+	}
+	if err := cert.SignCert(rand.Reader, ca); err != nil {
+		t.Fatalf("failed to sign certificate: %s", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: userKeyDER})),
+		string(ssh.MarshalAuthorizedKey(cert))
+}
+
+// openSSHStubSession opens a session on stub with the provider configuration c.
+func openSSHStubSession(t *testing.T, stub *sshNetconfStub, c configProvider) error {
+	t.Helper()
+	c.junosIP = "127.0.0.1"
+	c.junosPort = stub.port()
+	c.junosFilePermission = "644"
+	c.junosSSHHostKeyFinger = []string{stub.hostKeyFingerprint()}
+	sess, diags := c.prepareSession(context.Background())
+	if diags.HasError() {
+		t.Fatalf("prepareSession: %v", diags)
+	}
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		return err
+	}
+	if jnpr.SystemInformation.HardwareModel != "vsrx" {
+		t.Errorf("unexpected hardware model %q", jnpr.SystemInformation.HardwareModel)
+	}
+	sess.closeSession(jnpr)
+
+	return nil
+}
+
+func TestNetconfSSHCertificate(t *testing.T) {
+	ca := newSSHTestCA(t)
+	stub := startSSHNetconfStub(t, "terraform", "secret", sshStubOptions{
+		withoutPassword: true,
+		userCA:          ca.PublicKey(),
+	})
+	keyPEM, certPEM := newSSHTestCertificate(t, ca, "terraform")
+	if err := openSSHStubSession(t, stub, configProvider{
+		junosUserName:   "terraform",
+		junosSSHKeyPEM:  keyPEM,
+		junosSSHCertPEM: certPEM,
+	}); err != nil {
+		t.Fatalf("session not opened with certificate: %s", err)
+	}
+	if auths := stub.authMethods(); len(auths) != 1 || auths[0] != "certificate" {
+		t.Errorf("unexpected authentication methods %q", auths)
+	}
+	// certificate of another CA or for another principal
+	otherKeyPEM, otherCertPEM := newSSHTestCertificate(t, newSSHTestCA(t), "terraform")
+	if err := openSSHStubSession(t, stub, configProvider{
+		junosUserName:   "terraform",
+		junosSSHKeyPEM:  otherKeyPEM,
+		junosSSHCertPEM: otherCertPEM,
+	}); err == nil {
+		t.Error("session opened with a certificate of an unknown CA")
+	}
+	principalKeyPEM, principalCertPEM := newSSHTestCertificate(t, ca, "other")
+	if err := openSSHStubSession(t, stub, configProvider{
+		junosUserName:   "terraform",
+		junosSSHKeyPEM:  principalKeyPEM,
+		junosSSHCertPEM: principalCertPEM,
+	}); err == nil {
+		t.Error("session opened with a certificate for another principal")
+	}
+	// private key which doesn't match the certificate
+	err := openSSHStubSession(t, stub, configProvider{
+		junosUserName:   "terraform",
+		junosSSHKeyPEM:  otherKeyPEM,
+		junosSSHCertPEM: certPEM,
+	})
+	if err == nil || !strings.Contains(err.Error(), "private key doesn't match the certificate") {
+		t.Errorf("unexpected error with a private key which doesn't match the certificate: %v", err)
+	}
+}
+
+func TestNetconfSSHKeyboardInteractive(t *testing.T) {
+	stub := startSSHNetconfStub(t, "terraform", "secret", sshStubOptions{withoutPassword: true})
+	if err := openSSHStubSession(t, stub, configProvider{
+		junosUserName: "terraform",
+		junosPassword: "secret",
+	}); err != nil {
+		t.Fatalf("session not opened with keyboard-interactive: %s", err)
+	}
+	if auths := stub.authMethods(); len(auths) != 1 || auths[0] != "keyboard-interactive" {
+		t.Errorf("unexpected authentication methods %q", auths)
+	}
+	if err := openSSHStubSession(t, stub, configProvider{
+		junosUserName: "terraform",
+		junosPassword: "bad",
+	}); err == nil {
+		t.Error("session opened with a bad password")
+	}
+}
+
+func TestNetconfSimulatorSSH(t *testing.T) {
+	device := fakedevice.New("ex4300-48t")
+	server, err := netconfsim.Start(device, "terraform", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+	c := configProvider{
+		junosIP:               "127.0.0.1",
+		junosPort:             server.Port(),
+		junosUserName:         "terraform",
+		junosPassword:         "secret",
+		junosFilePermission:   "644",
+		junosSSHHostKeyFinger: []string{server.HostKeyFingerprint()},
+	}
+	sess, diags := c.prepareSession(context.Background())
+	if diags.HasError() {
+		t.Fatalf("prepareSession: %v", diags)
+	}
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession on 127.0.0.1:%d: %s", server.Port(), err)
+	}
+	defer sess.closeSession(jnpr)
+	if jnpr.SystemInformation.HardwareModel != "ex4300-48t" {
+		t.Errorf("unexpected hardware model %q", jnpr.SystemInformation.HardwareModel)
+	}
+	if err := sess.configLock(jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	if err := sess.configSet([]string{"set system host-name \"sim test\""}, jnpr); err != nil {
+		t.Fatalf("configSet: %s", err)
+	}
+	if _, err := sess.commitConf("test simulator", jnpr); err != nil {
+		t.Fatalf("commitConf: %s", err)
+	}
+	read, err := sess.command("show configuration system | display set relative", jnpr)
+	if err != nil {
+		t.Fatalf("command: %s", err)
+	}
+	if !strings.Contains(read, "set host-name \"sim test\"") {
+		t.Errorf("unexpected output of command %q", read)
+	}
+}
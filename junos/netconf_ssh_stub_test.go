@@ -0,0 +1,273 @@
+package junos
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const sshStubMsgSeparator = "]]>]]>"
+
+// sshStubOptions changes the authentication methods or the features of SSH stub.
+type sshStubOptions struct {
+	// withoutPassword disables the password authentication method
+	// (the password is only accepted with keyboard-interactive method).
+	withoutPassword bool
+	// forwarding accepts the `direct-tcpip` channels to use stub as jump host.
+	forwarding bool
+	// userCA accepts the user certificates signed by it (with username as principal).
+	userCA ssh.PublicKey
+}
+
+// sshNetconfStub is a local SSH server with the netconf subsystem answered by an in-memory stub device.
+type sshNetconfStub struct {
+	forwarding bool
+	listener   net.Listener
+	config     *ssh.ServerConfig
+	hostKey    ssh.PublicKey
+	device     *netconfStub
+	mutex      sync.Mutex
+	forwards   []string
+	auths      []string
+}
+
+// startSSHNetconfStub listens on a random port of 127.0.0.1 and accepts the username with password.
+func startSSHNetconfStub(t *testing.T, username, password string, options sshStubOptions) *sshNetconfStub {
+	t.Helper()
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stub := &sshNetconfStub{
+		forwarding: options.forwarding,
+		hostKey:    signer.PublicKey(),
+		device:     &netconfStub{},
+	}
+	checkPassword := func(user, pass string) error {
+		if user != username || pass != password {
+			return errors.New("authentication failed")
+		}
+
+		return nil
+	}
+	stub.config = &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if options.withoutPassword {
+				return nil, errors.New("password method disabled")
+			}
+			if err := checkPassword(conn.User(), string(pass)); err != nil {
+				return nil, err
+			}
+			stub.addAuth("password")
+
+			return nil, nil
+		},
+		KeyboardInteractiveCallback: func(
+			conn ssh.ConnMetadata, challenge ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+			answers, err := challenge("", "", []string{"Password:"}, []bool{false})
+			if err != nil {
+				return nil, err
+			}
+			if len(answers) != 1 {
+				return nil, errors.New("authentication failed")
+			}
+			if err := checkPassword(conn.User(), answers[0]); err != nil {
+				return nil, err
+			}
+			stub.addAuth("keyboard-interactive")
+
+			return nil, nil
+		},
+	}
+	if options.userCA != nil {
+		certChecker := &ssh.CertChecker{
+			IsUserAuthority: func(auth ssh.PublicKey) bool {
+				return bytes.Equal(auth.Marshal(), options.userCA.Marshal())
+			},
+		}
+		stub.config.PublicKeyCallback = func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if conn.User() != username {
+				return nil, errors.New("authentication failed")
+			}
+			permissions, err := certChecker.Authenticate(conn, key)
+			if err != nil {
+				return nil, err
+			}
+			stub.addAuth("certificate")
+
+			return permissions, nil
+		}
+	}
+	stub.config.AddHostKey(signer)
+	stub.listener, err = net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { stub.listener.Close() })
+	go func() {
+		for {
+			conn, err := stub.listener.Accept()
+			if err != nil {
+				return
+			}
+			go stub.serveConn(conn)
+		}
+	}()
+
+	return stub
+}
+
+func (stub *sshNetconfStub) port() int {
+	return stub.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (stub *sshNetconfStub) hostKeyFingerprint() string {
+	return ssh.FingerprintSHA256(stub.hostKey)
+}
+
+// forwarded returns the addresses of `direct-tcpip` channels opened by stub (in order of channels).
+func (stub *sshNetconfStub) forwarded() []string {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+
+	return append([]string{}, stub.forwards...)
+}
+
+// authMethods returns the authentication methods accepted by stub (in order of connections).
+func (stub *sshNetconfStub) authMethods() []string {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+
+	return append([]string{}, stub.auths...)
+}
+
+func (stub *sshNetconfStub) addAuth(method string) {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.auths = append(stub.auths, method)
+}
+
+func (stub *sshNetconfStub) serveConn(conn net.Conn) {
+	defer conn.Close()
+	sshConn, channels, requests, err := ssh.NewServerConn(conn, stub.config)
+	if err != nil {
+		return
+	}
+	defer sshConn.Close()
+	go ssh.DiscardRequests(requests)
+	for newChannel := range channels {
+		switch {
+		case newChannel.ChannelType() == "direct-tcpip" && stub.forwarding:
+			go stub.forwardChannel(newChannel)
+		case newChannel.ChannelType() == "session":
+			channel, channelRequests, err := newChannel.Accept()
+			if err != nil {
+				continue
+			}
+			go stub.serveChannel(channel, channelRequests)
+		default:
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+		}
+	}
+}
+
+// forwardChannel connects a `direct-tcpip` channel to its destination like a jump host.
+func (stub *sshNetconfStub) forwardChannel(newChannel ssh.NewChannel) {
+	var destination struct {
+		Host       string
+		Port       uint32
+		OriginHost string
+		OriginPort uint32
+	}
+	if err := ssh.Unmarshal(newChannel.ExtraData(), &destination); err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, "invalid destination")
+
+		return
+	}
+	address := net.JoinHostPort(destination.Host, fmt.Sprintf("%d", destination.Port))
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+
+		return
+	}
+	defer conn.Close()
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		return
+	}
+	defer channel.Close()
+	go ssh.DiscardRequests(requests)
+	stub.mutex.Lock()
+	stub.forwards = append(stub.forwards, address)
+	stub.mutex.Unlock()
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(conn, channel)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(channel, conn)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// serveChannel waits for the request of netconf subsystem and answers to the rpc on channel.
+func (stub *sshNetconfStub) serveChannel(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+	for req := range requests {
+		if req.Type != "subsystem" {
+			_ = req.Reply(false, nil)
+
+			continue
+		}
+		_ = req.Reply(true, nil)
+		go ssh.DiscardRequests(requests)
+
+		break
+	}
+	hello := "<hello><capabilities><capability>urn:ietf:params:netconf:base:1.0</capability></capabilities>" +
+		"<session-id>1</session-id></hello>"
+	if _, err := channel.Write([]byte(hello + sshStubMsgSeparator)); err != nil {
+		return
+	}
+	reader := bufio.NewReader(channel)
+	var msg bytes.Buffer
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return
+		}
+		msg.WriteByte(b)
+		if !bytes.HasSuffix(msg.Bytes(), []byte(sshStubMsgSeparator)) {
+			continue
+		}
+		request := msg.String()
+		msg.Reset()
+		if strings.Contains(request, "<hello") {
+			continue
+		}
+		reply := "<rpc-reply>" + stub.device.reply(request) + "</rpc-reply>"
+		if _, err := channel.Write([]byte(reply + sshStubMsgSeparator)); err != nil {
+			return
+		}
+		if strings.Contains(request, rpcClose) {
+			return
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package junos
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jeremmfr/go-netconf/netconf"
+)
+
+// netconfStub is an in-memory device answering to netconf rpc like a Junos device.
+type netconfStub struct {
+	mutex    sync.Mutex
+	requests []string
+}
+
+// netconfStubTransport is a netconf transport of a session on stub.
+type netconfStubTransport struct {
+	stub    *netconfStub
+	replies [][]byte
+}
+
+// open returns a new session on stub with the facts of device gathered.
+func (stub *netconfStub) open(t *testing.T) *NetconfObject {
+	t.Helper()
+	jnpr, err := newSessionFromNetconf(netconf.NewSession(&netconfStubTransport{stub: stub}))
+	if err != nil {
+		t.Fatalf("newSessionFromNetconf: %s", err)
+	}
+	if err := jnpr.gatherFacts(); err != nil {
+		t.Fatalf("gatherFacts: %s", err)
+	}
+
+	return jnpr
+}
+
+// dial returns a function to open sessions on stub.
+func (stub *netconfStub) dial() func() (netconfTransport, error) {
+	return func() (netconfTransport, error) {
+		return netconf.NewSession(&netconfStubTransport{stub: stub}), nil
+	}
+}
+
+// received returns the requests received by stub (except hello).
+func (stub *netconfStub) received() []string {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+
+	return append([]string{}, stub.requests...)
+}
+
+// count returns the number of requests received by stub which contain rpc.
+func (stub *netconfStub) count(rpc string) int {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	count := 0
+	for _, request := range stub.requests {
+		if strings.Contains(request, rpc) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (stub *netconfStub) reply(request string) string {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.requests = append(stub.requests, request)
+	if strings.Contains(request, rpcSystemInfo) {
+		return "<system-information><hardware-model>vsrx</hardware-model><os-name>junos</os-name>" +
+			"<host-name>stub</host-name></system-information>"
+	}
+
+	return "<ok/>"
+}
+
+func (t *netconfStubTransport) Send(data []byte) error {
+	t.replies = append(t.replies, []byte("<rpc-reply>"+t.stub.reply(string(data))+"</rpc-reply>"))
+
+	return nil
+}
+
+func (t *netconfStubTransport) Receive() ([]byte, error) {
+	if len(t.replies) == 0 {
+		return nil, errors.New("no request sent to stub")
+	}
+	reply := t.replies[0]
+	t.replies = t.replies[1:]
+
+	return reply, nil
+}
+
+func (t *netconfStubTransport) Close() error {
+	return nil
+}
+
+func (t *netconfStubTransport) ReceiveHello() (*netconf.HelloMessageReceive, error) {
+	return &netconf.HelloMessageReceive{SessionID: 1}, nil
+}
+
+func (t *netconfStubTransport) SendHello(*netconf.HelloMessageSend) error {
+	return nil
+}
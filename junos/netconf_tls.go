@@ -0,0 +1,128 @@
+package junos
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/jeremmfr/go-netconf/netconf"
+)
+
+const (
+	transportSSH = "ssh"
+	transportTLS = "tls"
+
+	netconfMsgSeparator = "]]>]]>"
+	tlsDialTimeout      = 30 * time.Second
+)
+
+// transportTLSConn is a netconf transport over TLS (RFC7589) with end-of-message framing.
+type transportTLSConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// netconfNewSessionTLS establishes a new connection over TLS to a NetconfObject device that we will use
+// to run our commands against.
+func netconfNewSessionTLS(host string, tlsConfig *tls.Config) (*NetconfObject, error) {
+	dialer := &net.Dialer{Timeout: tlsDialTimeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", host, tlsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s - %w", host, err)
+	}
+	t := &transportTLSConn{
+		conn:   conn,
+		reader: bufio.NewReader(conn),
+	}
+
+	return newSessionFromNetconf(netconf.NewSession(t))
+}
+
+// genTLSClientConfig returns the TLS configuration with the client certificate for mutual authentication
+// and the CA bundle to verify the certificate of device.
+func genTLSClientConfig(certPEM, keyPEM, caPEM []byte, serverName string) (*tls.Config, error) {
+	if len(certPEM) == 0 || len(keyPEM) == 0 {
+		return nil, errors.New("client certificate and key are required for netconf over TLS")
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate and key : %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ServerName:   serverName,
+		MinVersion:   tls.VersionTLS12,
+	}
+	if len(caPEM) > 0 {
+		caPool := x509.NewCertPool()
+		if !caPool.AppendCertsFromPEM(caPEM) {
+			return nil, errors.New("failed to load CA bundle, no certificate found")
+		}
+		tlsConfig.RootCAs = caPool
+	}
+
+	return tlsConfig, nil
+}
+
+// Send sends a netconf message with the end-of-message separator.
+func (t *transportTLSConn) Send(data []byte) error {
+	msg := make([]byte, 0, len(data)+len(netconfMsgSeparator)+1)
+	msg = append(msg, data...)
+	msg = append(msg, []byte(netconfMsgSeparator+"\n")...)
+	if _, err := t.conn.Write(msg); err != nil {
+		return fmt.Errorf("failed to write on TLS connection : %w", err)
+	}
+
+	return nil
+}
+
+// Receive reads a netconf message until the end-of-message separator.
+func (t *transportTLSConn) Receive() ([]byte, error) {
+	var out bytes.Buffer
+	separator := []byte(netconfMsgSeparator)
+	for {
+		b, err := t.reader.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errors.New("TLS connection closed by device")
+			}
+
+			return nil, fmt.Errorf("failed to read on TLS connection : %w", err)
+		}
+		out.WriteByte(b)
+		if bytes.HasSuffix(out.Bytes(), separator) {
+			return bytes.TrimSuffix(out.Bytes(), separator), nil
+		}
+	}
+}
+
+func (t *transportTLSConn) Close() error {
+	return t.conn.Close()
+}
+
+func (t *transportTLSConn) ReceiveHello() (*netconf.HelloMessageReceive, error) {
+	hello := new(netconf.HelloMessageReceive)
+	val, err := t.Receive()
+	if err != nil {
+		return hello, err
+	}
+	err = xml.Unmarshal(val, hello)
+
+	return hello, err
+}
+
+func (t *transportTLSConn) SendHello(hello *netconf.HelloMessageSend) error {
+	val, err := xml.Marshal(hello)
+	if err != nil {
+		return err
+	}
+
+	return t.Send(append([]byte(xml.Header), val...))
+}
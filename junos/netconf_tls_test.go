@@ -0,0 +1,267 @@
+package junos
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const tlsStubHello = `<?xml version="1.0" encoding="UTF-8"?>
+<hello xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">
+<capabilities><capability>urn:ietf:params:netconf:base:1.0</capability></capabilities>
+<session-id>1</session-id>
+</hello>`
+
+// tlsStubPKI is a CA with a certificate for stub and a certificate for client.
+type tlsStubPKI struct {
+	caPEM      []byte
+	serverCert tls.Certificate
+	clientCert []byte
+	clientKey  []byte
+}
+
+func newTLSStubPKI(t *testing.T) tlsStubPKI {
+	t.Helper()
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "stub-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	genCert := func(serial int64, name string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	}
+	pki := tlsStubPKI{
+		caPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+	}
+	serverCertPEM, serverKeyPEM := genCert(2, "stub", x509.ExtKeyUsageServerAuth)
+	pki.serverCert, err = tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pki.clientCert, pki.clientKey = genCert(3, "netconf", x509.ExtKeyUsageClientAuth)
+
+	return pki
+}
+
+// tlsNetconfStub is a local device answering to netconf rpc over TLS.
+type tlsNetconfStub struct {
+	dropped  bool
+	port     int
+	dropOnce string
+	compare  string
+	mutex    sync.Mutex
+	requests []string
+}
+
+// startTLSNetconfStub listens on a local port and answers to netconf rpc like a Junos device.
+func startTLSNetconfStub(t *testing.T, pki tlsStubPKI) *tlsNetconfStub {
+	t.Helper()
+	caPool := x509.NewCertPool()
+	caPool.AppendCertsFromPEM(pki.caPEM)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{
+		Certificates: []tls.Certificate{pki.serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    caPool,
+		MinVersion:   tls.VersionTLS12,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	stub := &tlsNetconfStub{
+		port: listener.Addr().(*net.TCPAddr).Port,
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go stub.serve(conn)
+		}
+	}()
+
+	return stub
+}
+
+// received returns the requests received by stub (except hello).
+func (stub *tlsNetconfStub) received() []string {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+
+	return append([]string{}, stub.requests...)
+}
+
+// drop returns true if the connection need to be closed without reply
+// (only the first request with dropOnce).
+func (stub *tlsNetconfStub) drop(request string) bool {
+	stub.mutex.Lock()
+	defer stub.mutex.Unlock()
+	stub.requests = append(stub.requests, request)
+	if stub.dropOnce != "" && !stub.dropped && strings.Contains(request, stub.dropOnce) {
+		stub.dropped = true
+
+		return true
+	}
+
+	return false
+}
+
+func (stub *tlsNetconfStub) serve(conn net.Conn) {
+	defer conn.Close()
+	if _, err := conn.Write([]byte(tlsStubHello + netconfMsgSeparator)); err != nil {
+		return
+	}
+	reader := bufio.NewReader(conn)
+	var msg bytes.Buffer
+	for {
+		b, err := reader.ReadByte()
+		if err != nil {
+			return
+		}
+		msg.WriteByte(b)
+		if !bytes.HasSuffix(msg.Bytes(), []byte(netconfMsgSeparator)) {
+			continue
+		}
+		request := msg.String()
+		msg.Reset()
+		var reply string
+		switch {
+		case strings.Contains(request, "<hello"):
+			continue
+		case stub.drop(request):
+			return
+		case strings.Contains(request, "<command"):
+			reply = "<configuration-output>command output</configuration-output>"
+		case strings.Contains(request, rpcGetCommittedConfigSet):
+			reply = "<configuration-set>set system host-name stub\n" +
+				"set routing-options static route 192.0.2.0/24 discard\n</configuration-set>"
+		case strings.Contains(request, rpcGetCommittedConfigXML):
+			reply = "<configuration><routing-options><static><route><name>192.0.2.0/24</name><discard/>" +
+				"</route></static></routing-options></configuration>"
+		case strings.Contains(request, rpcGetCompareRollback):
+			stub.mutex.Lock()
+			reply = "<configuration-information><configuration-output>" + stub.compare +
+				"</configuration-output></configuration-information>"
+			stub.mutex.Unlock()
+		case strings.Contains(request, rpcSystemInfo):
+			reply = "<system-information><hardware-model>vsrx</hardware-model><os-name>junos</os-name>" +
+				"<host-name>stub</host-name></system-information>"
+		case strings.Contains(request, "<hang/>"):
+			// device that stops responding
+			continue
+		case strings.Contains(request, "<close-session/>"):
+			_, _ = conn.Write([]byte("<rpc-reply><ok/></rpc-reply>" + netconfMsgSeparator))
+
+			return
+		default:
+			reply = "<ok/>"
+		}
+		if _, err := conn.Write([]byte("<rpc-reply>" + reply + "</rpc-reply>" + netconfMsgSeparator)); err != nil {
+			return
+		}
+	}
+}
+
+func TestNetconfTLSSession(t *testing.T) {
+	pki := newTLSStubPKI(t)
+	stub := startTLSNetconfStub(t, pki)
+	c := configProvider{
+		junosIP:             "127.0.0.1",
+		junosPort:           stub.port,
+		junosTransport:      transportTLS,
+		junosTLSCertPEM:     string(pki.clientCert),
+		junosTLSKeyPEM:      string(pki.clientKey),
+		junosTLSCABundlePEM: string(pki.caPEM),
+		junosFilePermission: "644",
+	}
+	sess, diags := c.prepareSession(context.Background())
+	if diags.HasError() {
+		t.Fatalf("prepareSession: %v", diags)
+	}
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession on 127.0.0.1:%d: %s", stub.port, err)
+	}
+	if jnpr.SystemInformation.HardwareModel != "vsrx" {
+		t.Errorf("unexpected hardware model %q", jnpr.SystemInformation.HardwareModel)
+	}
+	if err := jnpr.close(0); err != nil {
+		t.Errorf("close: %s", err)
+	}
+}
+
+func TestNetconfTLSSessionUnknownCA(t *testing.T) {
+	pki := newTLSStubPKI(t)
+	stub := startTLSNetconfStub(t, pki)
+	otherPKI := newTLSStubPKI(t)
+	tlsConfig, err := genTLSClientConfig(pki.clientCert, pki.clientKey, otherPKI.caPEM, "127.0.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jnpr, err := netconfNewSessionTLS(net.JoinHostPort("127.0.0.1", strconv.Itoa(stub.port)), tlsConfig)
+	if err == nil {
+		jnpr.closeTransport()
+		t.Fatal("session established with certificate of stub signed by an unknown CA")
+	}
+}
+
+func TestGenTLSClientConfigWithoutKey(t *testing.T) {
+	pki := newTLSStubPKI(t)
+	if _, err := genTLSClientConfig(pki.clientCert, nil, pki.caPEM, "127.0.0.1"); err == nil {
+		t.Fatal("TLS configuration generated without client key")
+	}
+}
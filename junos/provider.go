@@ -6,16 +6,20 @@ import (
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const defaultResourceTimeout = 20 * time.Minute
+
 var mutex = &sync.Mutex{} // nolint: gochecknoglobals
 
 // Provider junos for terraform.
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"ip": {
 				Type:        schema.TypeString,
@@ -27,6 +31,13 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("JUNOS_PORT", 830),
 			},
+			"transport": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_TRANSPORT", transportSSH),
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice([]string{transportSSH, transportTLS}, false)),
+			},
 			"username": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -47,16 +58,81 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("JUNOS_KEYFILE", nil),
 			},
+			"sshcert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_CERTPEM", nil),
+			},
+			"sshcertfile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_CERTFILE", nil),
+			},
 			"keypass": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("JUNOS_KEYPASS", nil),
 			},
+			"tls_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_TLS_CERTPEM", nil),
+			},
+			"tls_certfile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_TLS_CERTFILE", nil),
+			},
+			"tls_key_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_TLS_KEYPEM", nil),
+			},
+			"tls_keyfile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_TLS_KEYFILE", nil),
+			},
+			"tls_ca_bundle_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_TLS_CA_BUNDLE_PEM", nil),
+			},
+			"tls_ca_bundle_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_TLS_CA_BUNDLE_FILE", nil),
+			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_TLS_SERVER_NAME", nil),
+			},
 			"group_interface_delete": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("JUNOS_GROUP_INTERFACE_DELETE", nil),
 			},
+			"config_group": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("JUNOS_CONFIG_GROUP", nil),
+				ValidateDiagFunc: validateNameObjectJunos([]string{junosDefaultsGroup}, 64, formatDefAndDots),
+			},
+			"logical_system": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("JUNOS_LOGICAL_SYSTEM", nil),
+				ConflictsWith:    []string{"tenant"},
+				ValidateDiagFunc: validateNameObjectJunos([]string{}, 64, formatDefault),
+			},
+			"tenant": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("JUNOS_TENANT", nil),
+				ConflictsWith:    []string{"logical_system"},
+				ValidateDiagFunc: validateNameObjectJunos([]string{}, 64, formatDefault),
+			},
 			"cmd_sleep_short": {
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -67,17 +143,185 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("JUNOS_SLEEP_LOCK", 10),
 			},
+			"cmd_lock_max_wait": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("JUNOS_LOCK_MAX_WAIT", 0),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+			"cmd_rpc_timeout": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("JUNOS_RPC_TIMEOUT", 0),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+			"cmd_retry_attempts": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("JUNOS_RETRY_ATTEMPTS", 0),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+			"cmd_retry_backoff": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("JUNOS_RETRY_BACKOFF", 2),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
+			"config_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: EnvDefaultBooleanFunc("JUNOS_CONFIG_CACHE"),
+			},
+			"config_mode": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_CONFIG_MODE", configModeExclusive),
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice([]string{configModeExclusive, configModePrivate}, false)),
+			},
 			"ssh_sleep_closed": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("JUNOS_SLEEP_SSH_CLOSED", 0),
 			},
+			"ssh_jump_host": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"host": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      22,
+							ValidateFunc: validation.IsPortNumber,
+						},
+						"username": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"password": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"sshkey_pem": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"sshkeyfile": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"sshcert_pem": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"sshcertfile": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"keypass": {
+							Type:      schema.TypeString,
+							Optional:  true,
+							Sensitive: true,
+						},
+						"ssh_known_hosts_file": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"ssh_host_key_fingerprints": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"ssh_session_pool_size": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("JUNOS_SSH_SESSION_POOL_SIZE", 0),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+			},
 			"ssh_ciphers": {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				DefaultFunc: defaultSSHCiphers(),
 			},
+			"ssh_known_hosts_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_SSH_KNOWN_HOSTS_FILE", ""),
+			},
+			"ssh_host_key_fingerprints": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"ssh_host_key_trust_on_first_use": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				RequiredWith: []string{"ssh_known_hosts_file"},
+				DefaultFunc:  EnvDefaultBooleanFunc("JUNOS_SSH_HOST_KEY_TOFU"),
+			},
+			"commit_deferred": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: EnvDefaultBooleanFunc("JUNOS_COMMIT_DEFERRED"),
+			},
+			"commit_show_compare": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: EnvDefaultBooleanFunc("JUNOS_COMMIT_SHOW_COMPARE"),
+			},
+			"commit_show_compare_file": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: EnvDefaultBooleanFunc("JUNOS_COMMIT_SHOW_COMPARE_FILE"),
+			},
+			"plan_commit_check": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: EnvDefaultBooleanFunc("JUNOS_PLAN_COMMIT_CHECK"),
+			},
+			"commit_comment_template": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_COMMIT_COMMENT_TEMPLATE", ""),
+			},
+			"commit_comment_vars": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"commit_synchronize": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: EnvDefaultBooleanFunc("JUNOS_COMMIT_SYNCHRONIZE"),
+			},
+			"commit_force_synchronize": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: EnvDefaultBooleanFunc("JUNOS_COMMIT_FORCE_SYNCHRONIZE"),
+			},
+			"commit_confirmed": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("JUNOS_COMMIT_CONFIRMED", 0),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(0, 65535)),
+			},
+			"commit_confirmed_check_interval": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("JUNOS_COMMIT_CONFIRMED_CHECK_INTERVAL", 5),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 600)),
+			},
 			"file_permission": {
 				Type:             schema.TypeString,
 				Optional:         true,
@@ -94,6 +338,25 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("JUNOS_FAKECREATE_SETFILE", ""),
 			},
+			"offline_config_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("JUNOS_OFFLINE_CONFIG_FILE", ""),
+				ConflictsWith: []string{"fake_create_with_setfile"},
+			},
+			"offline_hardware_model": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("JUNOS_OFFLINE_HARDWARE_MODEL", ""),
+				RequiredWith: []string{"offline_config_file"},
+			},
+			"fake_create_with_setfile_format": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("JUNOS_FAKECREATE_SETFILE_FORMAT", setFileFormatSet),
+				ValidateDiagFunc: validation.ToDiagFunc(
+					validation.StringInSlice([]string{setFileFormatSet, setFileFormatText, setFileFormatXML}, false)),
+			},
 			"fake_update_also": {
 				Type:         schema.TypeBool,
 				Optional:     true,
@@ -116,6 +379,8 @@ func Provider() *schema.Provider {
 			"junos_bgp_neighbor":                                         resourceBgpNeighbor(),
 			"junos_bridge_domain":                                        resourceBridgeDomain(),
 			"junos_chassis_cluster":                                      resourceChassisCluster(),
+			"junos_commit":                                               resourceCommit(),
+			"junos_config_lines":                                         resourceConfigLines(),
 			"junos_eventoptions_destination":                             resourceEventoptionsDestination(),
 			"junos_eventoptions_generate_event":                          resourceEventoptionsGenerateEvent(),
 			"junos_eventoptions_policy":                                  resourceEventoptionsPolicy(),
@@ -124,12 +389,14 @@ func Provider() *schema.Provider {
 			"junos_firewall_policer":                                     resourceFirewallPolicer(),
 			"junos_forwardingoptions_sampling_instance":                  resourceForwardingoptionsSamplingInstance(),
 			"junos_generate_route":                                       resourceGenerateRoute(),
+			"junos_group":                                                resourceGroup(),
 			"junos_group_dual_system":                                    resourceGroupDualSystem(),
 			"junos_interface":                                            resourceInterface(),
 			"junos_interface_logical":                                    resourceInterfaceLogical(),
 			"junos_interface_physical":                                   resourceInterfacePhysical(),
 			"junos_interface_physical_disable":                           resourceInterfacePhysicalDisable(),
 			"junos_interface_st0_unit":                                   resourceInterfaceSt0Unit(),
+			"junos_logical_system":                                       resourceLogicalSystem(),
 			"junos_null_commit_file":                                     resourceNullCommitFile(),
 			"junos_ospf":                                                 resourceOspf(),
 			"junos_ospf_area":                                            resourceOspfArea(),
@@ -203,14 +470,41 @@ func Provider() *schema.Provider {
 			"junos_vlan":                                                 resourceVlan(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
+			"junos_configuration":               dataSourceConfiguration(),
 			"junos_interface":                   dataSourceInterface(),
 			"junos_interface_logical":           dataSourceInterfaceLogical(),
 			"junos_interface_physical":          dataSourceInterfacePhysical(),
 			"junos_interfaces_physical_present": dataSourceInterfacesPhysicalPresent(),
+			"junos_rpc":                         dataSourceRPC(),
 			"junos_system_information":          dataSourceSystemInformation(),
 		},
 		ConfigureContextFunc: configureProvider,
 	}
+	for name, resource := range provider.ResourcesMap {
+		addResourceTimeouts(resource)
+		addResourcePlanCommitCheck(name, resource)
+		addResourceCommitComment(name, resource)
+		addResourceConfigPrefix(name, resource)
+	}
+
+	return provider
+}
+
+// addResourceTimeouts allows to customize the timeouts of each operation of resource
+// with a `timeouts` block (defaults to 20 minutes like the SDK).
+func addResourceTimeouts(resource *schema.Resource) {
+	if resource.Timeouts != nil {
+		return
+	}
+	resource.Timeouts = &schema.ResourceTimeout{
+		Create:  schema.DefaultTimeout(defaultResourceTimeout),
+		Read:    schema.DefaultTimeout(defaultResourceTimeout),
+		Delete:  schema.DefaultTimeout(defaultResourceTimeout),
+		Default: schema.DefaultTimeout(defaultResourceTimeout),
+	}
+	if resource.UpdateContext != nil {
+		resource.Timeouts.Update = schema.DefaultTimeout(defaultResourceTimeout)
+	}
 }
 
 func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -220,29 +514,101 @@ func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}
 				"'fake_create_with_setfile' need to be set with 'fake_update_also' and 'fake_delete_also'"))
 		}
 	}
+	if d.Get("offline_config_file").(string) != "" && d.Get("offline_hardware_model").(string) == "" {
+		return nil, diag.FromErr(fmt.Errorf(
+			"'offline_hardware_model' need to be set with 'offline_config_file'"))
+	}
+	if d.Get("ssh_host_key_trust_on_first_use").(bool) && d.Get("ssh_known_hosts_file").(string) == "" {
+		return nil, diag.FromErr(fmt.Errorf(
+			"'ssh_known_hosts_file' need to be set with 'ssh_host_key_trust_on_first_use'"))
+	}
+	if d.Get("transport").(string) == transportTLS && len(d.Get("ssh_jump_host").([]interface{})) > 0 {
+		return nil, diag.FromErr(fmt.Errorf(
+			"'ssh_jump_host' can't be set with 'transport' = \"%s\"", transportTLS))
+	}
 	c := configProvider{
 		junosIP:                  d.Get("ip").(string),
 		junosPort:                d.Get("port").(int),
+		junosTransport:           d.Get("transport").(string),
 		junosUserName:            d.Get("username").(string),
 		junosPassword:            d.Get("password").(string),
 		junosSSHKeyPEM:           d.Get("sshkey_pem").(string),
 		junosSSHKeyFile:          d.Get("sshkeyfile").(string),
+		junosSSHCertPEM:          d.Get("sshcert_pem").(string),
+		junosSSHCertFile:         d.Get("sshcertfile").(string),
 		junosKeyPass:             d.Get("keypass").(string),
+		junosTLSCertPEM:          d.Get("tls_cert_pem").(string),
+		junosTLSCertFile:         d.Get("tls_certfile").(string),
+		junosTLSKeyPEM:           d.Get("tls_key_pem").(string),
+		junosTLSKeyFile:          d.Get("tls_keyfile").(string),
+		junosTLSCABundlePEM:      d.Get("tls_ca_bundle_pem").(string),
+		junosTLSCABundleFile:     d.Get("tls_ca_bundle_file").(string),
+		junosTLSServerName:       d.Get("tls_server_name").(string),
 		junosGroupIntDel:         d.Get("group_interface_delete").(string),
+		junosConfigGroup:         d.Get("config_group").(string),
+		junosLogicalSystem:       d.Get("logical_system").(string),
+		junosTenant:              d.Get("tenant").(string),
 		junosCmdSleepShort:       d.Get("cmd_sleep_short").(int),
 		junosCmdSleepLock:        d.Get("cmd_sleep_lock").(int),
+		junosCmdLockMaxWait:      d.Get("cmd_lock_max_wait").(int),
+		junosCmdRPCTimeout:       d.Get("cmd_rpc_timeout").(int),
+		junosRetryAttempts:       d.Get("cmd_retry_attempts").(int),
+		junosRetryBackoff:        d.Get("cmd_retry_backoff").(int),
+		junosConfigMode:          d.Get("config_mode").(string),
+		junosConfigCache:         d.Get("config_cache").(bool),
 		junosSSHSleepClosed:      d.Get("ssh_sleep_closed").(int),
+		junosSessionPoolSize:     d.Get("ssh_session_pool_size").(int),
+		junosSSHKnownHostsFile:   d.Get("ssh_known_hosts_file").(string),
+		junosSSHHostKeyTOFU:      d.Get("ssh_host_key_trust_on_first_use").(bool),
+		junosCommitDeferred:      d.Get("commit_deferred").(bool),
+		junosCommitConfirmed:     d.Get("commit_confirmed").(int),
+		junosConfirmedCheck:      d.Get("commit_confirmed_check_interval").(int),
+		junosPlanCommitCheck:     d.Get("plan_commit_check").(bool),
+		junosShowCompare:         d.Get("commit_show_compare").(bool),
+		junosShowCompareFile:     d.Get("commit_show_compare_file").(bool),
+		junosCommitSynchronize:   d.Get("commit_synchronize").(bool),
+		junosCommitForceSync:     d.Get("commit_force_synchronize").(bool),
+		junosCommentTemplate:     d.Get("commit_comment_template").(string),
+		junosCommitCommentVars:   make(map[string]string),
 		junosFilePermission:      d.Get("file_permission").(string),
 		junosDebugNetconfLogPath: d.Get("debug_netconf_log_path").(string),
 		junosFakeCreateSetFile:   d.Get("fake_create_with_setfile").(string),
+		junosFakeSetFileFormat:   d.Get("fake_create_with_setfile_format").(string),
+		junosOfflineConfigFile:   d.Get("offline_config_file").(string),
+		junosOfflineModel:        d.Get("offline_hardware_model").(string),
 		junosFakeUpdateAlso:      d.Get("fake_update_also").(bool),
 		junosFakeDeleteAlso:      d.Get("fake_delete_also").(bool),
 	}
+	for k, v := range d.Get("commit_comment_vars").(map[string]interface{}) {
+		c.junosCommitCommentVars[k] = v.(string)
+	}
 	for _, v := range d.Get("ssh_ciphers").([]interface{}) {
 		c.junosSSHCiphers = append(c.junosSSHCiphers, v.(string))
 	}
+	for _, v := range d.Get("ssh_host_key_fingerprints").([]interface{}) {
+		c.junosSSHHostKeyFinger = append(c.junosSSHHostKeyFinger, v.(string))
+	}
+	for _, v := range d.Get("ssh_jump_host").([]interface{}) {
+		jumpHost := v.(map[string]interface{})
+		jumpHostConfig := configSSHJumpHost{
+			host:           jumpHost["host"].(string),
+			port:           jumpHost["port"].(int),
+			username:       jumpHost["username"].(string),
+			password:       jumpHost["password"].(string),
+			sshKeyPEM:      jumpHost["sshkey_pem"].(string),
+			sshKeyFile:     jumpHost["sshkeyfile"].(string),
+			keyPass:        jumpHost["keypass"].(string),
+			knownHostsFile: jumpHost["ssh_known_hosts_file"].(string),
+			sshCertPEM:     jumpHost["sshcert_pem"].(string),
+			sshCertFile:    jumpHost["sshcertfile"].(string),
+		}
+		for _, v2 := range jumpHost["ssh_host_key_fingerprints"].([]interface{}) {
+			jumpHostConfig.hostKeyFinger = append(jumpHostConfig.hostKeyFinger, v2.(string))
+		}
+		c.junosSSHJumpHosts = append(c.junosSSHJumpHosts, jumpHostConfig)
+	}
 
-	return c.prepareSession()
+	return c.prepareSession(ctx)
 }
 
 func EnvDefaultBooleanFunc(k string) schema.SchemaDefaultFunc {
@@ -0,0 +1,64 @@
+package junos
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// providerServer is the gRPC server of provider which follows the changes planned by resources
+// to plan an update of junos_commit resource with the deferred commit
+// and returns the skipped plan commit checks as warnings of plan.
+type providerServer struct {
+	tfprotov5.ProviderServer
+	provider *schema.Provider
+}
+
+// ProviderServer returns the gRPC server of provider junos.
+func ProviderServer() tfprotov5.ProviderServer {
+	provider := Provider()
+
+	return &providerServer{
+		ProviderServer: schema.NewGRPCProviderServer(provider),
+		provider:       provider,
+	}
+}
+
+func (s *providerServer) PlanResourceChange(
+	ctx context.Context, req *tfprotov5.PlanResourceChangeRequest,
+) (*tfprotov5.PlanResourceChangeResponse, error) {
+	warnings := &planCommitCheckWarnings{}
+	resp, err := s.ProviderServer.PlanResourceChange(withPlanCommitCheckWarnings(ctx, warnings), req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+	for _, reason := range warnings.reasons {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityWarning,
+			Summary:  reason,
+		})
+	}
+	sess, ok := s.provider.Meta().(*Session)
+	if ok && sess.deferred != nil && req.TypeName != "junos_commit" &&
+		plannedChange(req, resp) {
+		sess.deferred.planChange(req.TypeName)
+	}
+
+	return resp, nil
+}
+
+// plannedChange returns true if the planned state of resource is different from its prior state
+// (the planned state is the prior state without change).
+func plannedChange(req *tfprotov5.PlanResourceChangeRequest, resp *tfprotov5.PlanResourceChangeResponse) bool {
+	if len(resp.RequiresReplace) > 0 {
+		return true
+	}
+	if req.PriorState == nil || resp.PlannedState == nil {
+		return req.PriorState != resp.PlannedState
+	}
+
+	return !bytes.Equal(req.PriorState.MsgPack, resp.PlannedState.MsgPack) ||
+		!bytes.Equal(req.PriorState.JSON, resp.PlannedState.JSON)
+}
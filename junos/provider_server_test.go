@@ -0,0 +1,28 @@
+package junos
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPlannedChange(t *testing.T) {
+	prior := &tfprotov5.DynamicValue{MsgPack: []byte{0x81, 0xa2, 'i', 'd', 0xa1, 'a'}}
+	changed := &tfprotov5.DynamicValue{MsgPack: []byte{0x81, 0xa2, 'i', 'd', 0xa1, 'b'}}
+	if plannedChange(&tfprotov5.PlanResourceChangeRequest{PriorState: prior},
+		&tfprotov5.PlanResourceChangeResponse{PlannedState: prior}) {
+		t.Error("change detected with planned state equal to prior state")
+	}
+	if !plannedChange(&tfprotov5.PlanResourceChangeRequest{PriorState: prior},
+		&tfprotov5.PlanResourceChangeResponse{PlannedState: changed}) {
+		t.Error("change not detected with planned state different from prior state")
+	}
+	if !plannedChange(&tfprotov5.PlanResourceChangeRequest{PriorState: prior},
+		&tfprotov5.PlanResourceChangeResponse{
+			PlannedState:    prior,
+			RequiresReplace: []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("id")},
+		}) {
+		t.Error("change not detected with replace")
+	}
+}
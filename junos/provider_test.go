@@ -2,10 +2,14 @@ package junos_test
 
 import (
 	"context"
+	"log"
 	"os"
+	"strconv"
 	"testing"
 
 	"github.com/jeremmfr/terraform-provider-junos/junos"
+	"github.com/jeremmfr/terraform-provider-junos/junos/internal/fakedevice"
+	"github.com/jeremmfr/terraform-provider-junos/junos/internal/netconfsim"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -24,6 +28,60 @@ const (
 	defaultInterfaceSwitchTestAcc = "xe-0/0/3"
 )
 
+// export TESTACC_SIMULATOR not empty to run testacc against a local netconf simulator instead of a device
+// (JUNOS_HOST, JUNOS_PORT, JUNOS_USERNAME and JUNOS_PASSWORD are set to connect to the simulator)
+// export TESTACC_SIMULATOR_MODEL to change the hardware model reported by the simulator
+// (defaults to vsrx, vmx with TESTACC_ROUTER and ex4300-48t with TESTACC_SWITCH)
+
+func TestMain(m *testing.M) {
+	os.Exit(runTestMain(m))
+}
+
+func runTestMain(m *testing.M) int {
+	if os.Getenv("TESTACC_SIMULATOR") == "" {
+		return m.Run()
+	}
+	device := fakedevice.New(simulatorModel())
+	device.SetInterfaces("ge-0/0/0", "ge-0/0/1", "ge-0/0/2", defaultInterfaceTestAcc, defaultInterfaceTestAcc2,
+		"xe-0/0/0", "xe-0/0/1", "xe-0/0/2", defaultInterfaceSwitchTestAcc)
+	server, err := netconfsim.Start(device, "terraform", "terraform")
+	if err != nil {
+		log.Printf("failed to start netconf simulator: %s", err)
+
+		return 1
+	}
+	defer server.Close()
+	for k, v := range map[string]string{
+		"JUNOS_HOST":     "127.0.0.1",
+		"JUNOS_PORT":     strconv.Itoa(server.Port()),
+		"JUNOS_USERNAME": "terraform",
+		"JUNOS_PASSWORD": "terraform",
+	} {
+		if err := os.Setenv(k, v); err != nil {
+			log.Printf("failed to set %s: %s", k, err)
+
+			return 1
+		}
+	}
+	log.Printf("netconf simulator (%s) listen on 127.0.0.1:%d", device.Model(), server.Port())
+
+	return m.Run()
+}
+
+// simulatorModel returns the hardware model reported by the netconf simulator.
+func simulatorModel() string {
+	switch {
+	case os.Getenv("TESTACC_SIMULATOR_MODEL") != "":
+		return os.Getenv("TESTACC_SIMULATOR_MODEL")
+	case os.Getenv("TESTACC_ROUTER") != "":
+		return "vmx"
+	case os.Getenv("TESTACC_SWITCH") != "":
+		return "ex4300-48t"
+	default:
+		return "vsrx"
+	}
+}
+
 func TestProvider(t *testing.T) {
 	if err := junos.Provider().InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
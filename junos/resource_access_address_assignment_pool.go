@@ -29,7 +29,7 @@ func resourceAccessAddressAssignPool() *schema.Resource {
 		UpdateContext: resourceAccessAddressAssignPoolUpdate,
 		DeleteContext: resourceAccessAddressAssignPoolDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceAccessAddressAssignPoolImport,
+			StateContext: resourceAccessAddressAssignPoolImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -460,12 +460,14 @@ func resourceAccessAddressAssignPoolCreate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if d.Get("routing_instance").(string) != defaultWord {
 		instanceExists, err := checkRoutingInstanceExists(d.Get("routing_instance").(string), m, jnprSess)
@@ -525,7 +527,7 @@ func resourceAccessAddressAssignPoolCreate(ctx context.Context,
 
 func resourceAccessAddressAssignPoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -568,12 +570,14 @@ func resourceAccessAddressAssignPoolUpdate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delAccessAddressAssignPool(d.Get("name").(string), d.Get("routing_instance").(string),
 		m, jnprSess); err != nil {
@@ -609,12 +613,14 @@ func resourceAccessAddressAssignPoolDelete(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delAccessAddressAssignPool(d.Get("name").(string), d.Get("routing_instance").(string),
 		m, jnprSess); err != nil {
@@ -633,9 +639,10 @@ func resourceAccessAddressAssignPoolDelete(ctx context.Context,
 	return diagWarns
 }
 
-func resourceAccessAddressAssignPoolImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceAccessAddressAssignPoolImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,114 @@
+package junos
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCommit() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCommitCreate,
+		ReadContext:   resourceCommitRead,
+		UpdateContext: resourceCommitUpdate,
+		DeleteContext: resourceCommitDelete,
+		CustomizeDiff: resourceCommitCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"log_message": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"commit_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     nil,
+			},
+			"changes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceCommitCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	var diagWarns diag.Diagnostics
+	changes, warns, err := sess.commitDeferred(ctx, d.Get("log_message").(string))
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	d.SetId("commit")
+	if tfErr := d.Set("changes", changes); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return diagWarns
+}
+
+func resourceCommitRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourceCommitUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	var diagWarns diag.Diagnostics
+	changes, warns, err := sess.commitDeferred(ctx, d.Get("log_message").(string))
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	if tfErr := d.Set("changes", changes); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return diagWarns
+}
+
+func resourceCommitDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	// no more commit after this resource in the Terraform run, next resources commit their changes
+	if sess.deferred != nil {
+		defer sess.deferred.destroy()
+	}
+	if d.Get("commit_on_destroy").(bool) {
+		var diagWarns diag.Diagnostics
+		_, warns, err := sess.commitDeferred(ctx, d.Get("log_message").(string))
+		appendDiagWarns(&diagWarns, warns)
+		if err != nil {
+			return append(diagWarns, diag.FromErr(err)...)
+		}
+
+		return diagWarns
+	}
+	if sess.deferred != nil {
+		if changes := sess.deferred.uncommitted(); len(changes) > 0 {
+			sess.deferred.discard(sess)
+
+			return diag.Errorf("deferred changes not committed and discarded (%s): "+
+				"commit_on_destroy needs to be true to commit them", strings.Join(changes, ", "))
+		}
+	}
+	d.SetId("")
+
+	return nil
+}
+
+// resourceCommitCustomizeDiff plans an update of resource when other resources have changes to commit
+// (even without change of triggers).
+func resourceCommitCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+	sess, ok := m.(*Session)
+	if !ok || sess.deferred == nil || !sess.deferred.hasPlannedChanges() {
+		return nil
+	}
+
+	return diff.SetNewComputed("changes")
+}
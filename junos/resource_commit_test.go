@@ -0,0 +1,101 @@
+package junos_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jeremmfr/terraform-provider-junos/junos"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccJunosCommit_basic(t *testing.T) {
+	if os.Getenv("TESTACC_SWITCH") == "" {
+		resource.Test(t, resource.TestCase{
+			PreCheck: func() { testAccPreCheck(t) },
+			// the gRPC server of provider plans the update of junos_commit when resources have changes
+			ProtoV5ProviderFactories: map[string]func() (tfprotov5.ProviderServer, error){
+				"junos": func() (tfprotov5.ProviderServer, error) {
+					return junos.ProviderServer(), nil
+				},
+			},
+			Steps: []resource.TestStep{
+				{
+					Config: testAccJunosCommitConfigCreate(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("junos_static_route.testacc_commit",
+							"routing_instance", "testacc_commit"),
+						resource.TestCheckResourceAttr("junos_commit.testacc_commit",
+							"id", "commit"),
+						resource.TestCheckResourceAttr("junos_commit.testacc_commit",
+							"changes.#", "2"),
+					),
+				},
+				{
+					Config: testAccJunosCommitConfigUpdate(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("junos_static_route.testacc_commit",
+							"preference", "101"),
+						resource.TestCheckResourceAttr("junos_commit.testacc_commit",
+							"changes.#", "1"),
+					),
+				},
+				{
+					Config:   testAccJunosCommitConfigUpdate(),
+					PlanOnly: true,
+				},
+			},
+		})
+	}
+}
+
+func testAccJunosCommitConfigCreate() string {
+	return `
+provider "junos" {
+  commit_deferred = true
+}
+resource junos_routing_instance "testacc_commit" {
+  name = "testacc_commit"
+}
+resource junos_static_route "testacc_commit" {
+  destination      = "192.0.2.0/25"
+  routing_instance = junos_routing_instance.testacc_commit.name
+  preference       = 100
+  discard          = true
+}
+resource junos_commit "testacc_commit" {
+  log_message       = "testacc commit"
+  commit_on_destroy = true
+  depends_on = [
+    junos_routing_instance.testacc_commit,
+    junos_static_route.testacc_commit,
+  ]
+}
+`
+}
+
+func testAccJunosCommitConfigUpdate() string {
+	return `
+provider "junos" {
+  commit_deferred = true
+}
+resource junos_routing_instance "testacc_commit" {
+  name = "testacc_commit"
+}
+resource junos_static_route "testacc_commit" {
+  destination      = "192.0.2.0/25"
+  routing_instance = junos_routing_instance.testacc_commit.name
+  preference       = 101
+  discard          = true
+}
+resource junos_commit "testacc_commit" {
+  log_message       = "testacc commit"
+  commit_on_destroy = true
+  depends_on = [
+    junos_routing_instance.testacc_commit,
+    junos_static_route.testacc_commit,
+  ]
+}
+`
+}
@@ -0,0 +1,358 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const configLinesRootID = "root"
+
+func resourceConfigLines() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceConfigLinesCreate,
+		ReadContext:   resourceConfigLinesRead,
+		UpdateContext: resourceConfigLinesUpdate,
+		DeleteContext: resourceConfigLinesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceConfigLinesImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"lines": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile(
+						`^set \S`), "must be a set line (start with 'set ')"),
+				},
+			},
+			"hierarchy": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringDoesNotMatch(regexp.MustCompile(
+					`^(set|delete) |^\s|\s$`), "must be a hierarchy without set/delete and spaces around"),
+			},
+			"exclusive": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				RequiredWith: []string{"hierarchy"},
+			},
+		},
+	}
+}
+
+func resourceConfigLinesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	if sess.junosFakeCreateSetFile != "" {
+		if err := setConfigLines(d, m, nil); err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(configLinesID(d.Get("hierarchy").(string)))
+
+		return nil
+	}
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
+	var diagWarns diag.Diagnostics
+	if d.Get("exclusive").(bool) {
+		if err := delConfigLinesHierarchy(d.Get("hierarchy").(string), m, jnprSess); err != nil {
+			appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+			return append(diagWarns, diag.FromErr(err)...)
+		}
+	}
+	if err := setConfigLines(d, m, jnprSess); err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	warns, err := sess.commitConf("create resource junos_config_lines", jnprSess)
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	d.SetId(configLinesID(d.Get("hierarchy").(string)))
+
+	return append(diagWarns, resourceConfigLinesReadWJnprSess(d, m, jnprSess)...)
+}
+
+func resourceConfigLinesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+
+	return resourceConfigLinesReadWJnprSess(d, m, jnprSess)
+}
+
+func resourceConfigLinesReadWJnprSess(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
+	mutex.Lock()
+	configLines, err := readConfigLines(d.Get("hierarchy").(string), m, jnprSess)
+	mutex.Unlock()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	stateLines := make([]string, 0)
+	for _, v := range d.Get("lines").([]interface{}) {
+		stateLines = append(stateLines, v.(string))
+	}
+	lines := compareConfigLines(stateLines, configLines, d.Get("exclusive").(bool))
+	if len(lines) == 0 {
+		d.SetId("")
+	} else if tfErr := d.Set("lines", lines); tfErr != nil {
+		panic(tfErr)
+	}
+
+	return nil
+}
+
+func resourceConfigLinesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	sess := m.(*Session)
+	if sess.junosFakeUpdateAlso {
+		if err := delConfigLinesRemoved(d, m, nil); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := setConfigLines(d, m, nil); err != nil {
+			return diag.FromErr(err)
+		}
+		d.Partial(false)
+
+		return nil
+	}
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
+	var diagWarns diag.Diagnostics
+	if err := delConfigLinesRemoved(d, m, jnprSess); err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	if err := setConfigLines(d, m, jnprSess); err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	warns, err := sess.commitConf("update resource junos_config_lines", jnprSess)
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	d.Partial(false)
+
+	return append(diagWarns, resourceConfigLinesReadWJnprSess(d, m, jnprSess)...)
+}
+
+func resourceConfigLinesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	if sess.junosFakeDeleteAlso {
+		if err := delConfigLines(d, m, nil); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
+	var diagWarns diag.Diagnostics
+	if err := delConfigLines(d, m, jnprSess); err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	warns, err := sess.commitConf("delete resource junos_config_lines", jnprSess)
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+
+	return diagWarns
+}
+
+func resourceConfigLinesImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.closeSession(jnprSess)
+	result := make([]*schema.ResourceData, 1)
+	if d.Id() == configLinesRootID {
+		return nil, fmt.Errorf("can't import the whole configuration (id must be <hierarchy>)")
+	}
+	configLines, err := readConfigLines(d.Id(), m, jnprSess)
+	if err != nil {
+		return nil, err
+	}
+	if len(configLines) == 0 {
+		return nil, fmt.Errorf("don't find configuration with id '%v' (id must be <hierarchy>)", d.Id())
+	}
+	if tfErr := d.Set("hierarchy", d.Id()); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("exclusive", true); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("lines", configLines); tfErr != nil {
+		panic(tfErr)
+	}
+	result[0] = d
+
+	return result, nil
+}
+
+// configLinesID returns the ID of resource with hierarchy.
+func configLinesID(hierarchy string) string {
+	if hierarchy == "" {
+		return configLinesRootID
+	}
+
+	return hierarchy
+}
+
+// configLinesPrefix returns the prefix of lines for hierarchy.
+func configLinesPrefix(action, hierarchy string) string {
+	if hierarchy == "" {
+		return action + " "
+	}
+
+	return action + " " + hierarchy + " "
+}
+
+func setConfigLines(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	sess := m.(*Session)
+	setPrefix := configLinesPrefix("set", d.Get("hierarchy").(string))
+	configSet := make([]string, 0)
+	for _, v := range d.Get("lines").([]interface{}) {
+		configSet = append(configSet, setPrefix+strings.TrimPrefix(strings.TrimSpace(v.(string)), "set "))
+	}
+
+	return sess.configSet(configSet, jnprSess)
+}
+
+// readConfigLines returns the `set` lines (relative to hierarchy) in configuration.
+func readConfigLines(hierarchy string, m interface{}, jnprSess *NetconfObject) ([]string, error) {
+	sess := m.(*Session)
+	showConfig, err := sess.command(strings.TrimSpace("show configuration "+hierarchy)+
+		" | display set relative", jnprSess)
+	if err != nil {
+		return nil, err
+	}
+	configLines := make([]string, 0)
+	if showConfig == emptyWord {
+		return configLines, nil
+	}
+	for _, item := range strings.Split(showConfig, "\n") {
+		if strings.HasPrefix(item, "set ") {
+			configLines = append(configLines, strings.TrimSpace(item))
+		}
+	}
+
+	return configLines, nil
+}
+
+// compareConfigLines returns the lines still present in configuration (a line is present if a line
+// of configuration is equal or under it) and, if exclusive, the lines of configuration not covered.
+func compareConfigLines(lines, configLines []string, exclusive bool) []string {
+	result := make([]string, 0, len(lines))
+	covered := make([]bool, len(configLines))
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		found := false
+		for i, configLine := range configLines {
+			if configLine == line || strings.HasPrefix(configLine, line+" ") {
+				found = true
+				covered[i] = true
+			}
+		}
+		if found {
+			result = append(result, line)
+		}
+	}
+	if exclusive {
+		for i, configLine := range configLines {
+			if !covered[i] {
+				result = append(result, configLine)
+			}
+		}
+	}
+
+	return result
+}
+
+// delConfigLinesRemoved deletes the lines removed from the previous lines of resource.
+func delConfigLinesRemoved(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	sess := m.(*Session)
+	delPrefix := configLinesPrefix("delete", d.Get("hierarchy").(string))
+	oldLines, newLines := d.GetChange("lines")
+	keep := make(map[string]bool)
+	for _, v := range newLines.([]interface{}) {
+		keep[strings.TrimSpace(v.(string))] = true
+	}
+	configSet := make([]string, 0)
+	for _, v := range oldLines.([]interface{}) {
+		if line := strings.TrimSpace(v.(string)); !keep[line] {
+			configSet = append(configSet, delPrefix+strings.TrimPrefix(line, "set "))
+		}
+	}
+	if len(configSet) == 0 {
+		return nil
+	}
+
+	return sess.configSet(configSet, jnprSess)
+}
+
+func delConfigLines(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	if d.Get("exclusive").(bool) {
+		return delConfigLinesHierarchy(d.Get("hierarchy").(string), m, jnprSess)
+	}
+	sess := m.(*Session)
+	delPrefix := configLinesPrefix("delete", d.Get("hierarchy").(string))
+	configSet := make([]string, 0)
+	for _, v := range d.Get("lines").([]interface{}) {
+		configSet = append(configSet, delPrefix+strings.TrimPrefix(strings.TrimSpace(v.(string)), "set "))
+	}
+
+	return sess.configSet(configSet, jnprSess)
+}
+
+func delConfigLinesHierarchy(hierarchy string, m interface{}, jnprSess *NetconfObject) error {
+	sess := m.(*Session)
+
+	return sess.configSet([]string{"delete " + hierarchy}, jnprSess)
+}
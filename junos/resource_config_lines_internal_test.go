@@ -0,0 +1,61 @@
+package junos
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestFakeDeviceResourceConfigLines(t *testing.T) {
+	sess, device := newFakeDeviceSession(t, "vsrx")
+	ctx := context.Background()
+	res := resourceConfigLines()
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"hierarchy": "snmp",
+		"lines": []interface{}{
+			"set location lab",
+			"set community public",
+		},
+	})
+	device.LoadCommitted("set snmp contact noc")
+	if diags := res.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "snmp" {
+		t.Errorf("unexpected id %q after create", d.Id())
+	}
+	committed := strings.Join(device.Committed(), "\n")
+	if !strings.Contains(committed, "set snmp location lab") || !strings.Contains(committed, "set snmp contact noc") {
+		t.Errorf("unexpected configuration after create:\n%s", committed)
+	}
+
+	// drift: a line removed and lines added on device
+	device.LoadCommitted(
+		"delete snmp location",
+		"set snmp community public authorization read-only",
+	)
+	if diags := res.ReadContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if lines := d.Get("lines").([]interface{}); len(lines) != 1 || lines[0].(string) != "set community public" {
+		t.Errorf("unexpected lines %q after read with drift", lines)
+	}
+	if err := d.Set("exclusive", true); err != nil {
+		t.Fatal(err)
+	}
+	if diags := res.ReadContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("read exclusive: %v", diags)
+	}
+	if lines := d.Get("lines").([]interface{}); len(lines) != 2 || lines[1].(string) != "set contact noc" {
+		t.Errorf("unexpected lines %q after read with exclusive", lines)
+	}
+
+	if diags := res.DeleteContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if committed := device.Committed(); len(committed) != 0 {
+		t.Errorf("configuration not empty after delete: %q", committed)
+	}
+}
@@ -0,0 +1,81 @@
+package junos_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccJunosConfigLines_basic(t *testing.T) {
+	if os.Getenv("TESTACC_SWITCH") == "" {
+		resource.Test(t, resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccJunosConfigLinesConfigCreate(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("junos_config_lines.testacc_configLines",
+							"lines.#", "3"),
+						resource.TestCheckResourceAttr("junos_config_lines.testacc_configLines",
+							"lines.0", "set location \"testacc configLines\""),
+						resource.TestCheckResourceAttr("junos_config_lines.testacc_configLines2",
+							"id", "root"),
+					),
+				},
+				{
+					Config: testAccJunosConfigLinesConfigUpdate(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("junos_config_lines.testacc_configLines",
+							"lines.#", "2"),
+						resource.TestCheckResourceAttr("junos_config_lines.testacc_configLines",
+							"lines.1", "set contact testacc@example.com"),
+					),
+				},
+				{
+					ResourceName:      "junos_config_lines.testacc_configLines",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	}
+}
+
+func testAccJunosConfigLinesConfigCreate() string {
+	return `
+resource junos_config_lines "testacc_configLines" {
+  hierarchy = "snmp"
+  exclusive = true
+  lines = [
+    "set location \"testacc configLines\"",
+    "set contact noc@example.com",
+    "set community testacc_configLines authorization read-only",
+  ]
+}
+resource junos_config_lines "testacc_configLines2" {
+  lines = [
+    "set policy-options prefix-list testacc_configLines 192.0.2.0/24",
+  ]
+}
+`
+}
+
+func testAccJunosConfigLinesConfigUpdate() string {
+	return `
+resource junos_config_lines "testacc_configLines" {
+  hierarchy = "snmp"
+  exclusive = true
+  lines = [
+    "set location \"testacc configLines\"",
+    "set contact testacc@example.com",
+  ]
+}
+resource junos_config_lines "testacc_configLines2" {
+  lines = [
+    "set policy-options prefix-list testacc_configLines 192.0.2.0/24",
+  ]
+}
+`
+}
@@ -26,7 +26,7 @@ func resourceEventoptionsGenerateEvent() *schema.Resource {
 		UpdateContext: resourceEventoptionsGenerateEventUpdate,
 		DeleteContext: resourceEventoptionsGenerateEventDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceEventoptionsGenerateEventImport,
+			StateContext: resourceEventoptionsGenerateEventImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -66,12 +66,14 @@ func resourceEventoptionsGenerateEventCreate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	eventoptionsGenerateEventExists, err := checkEventoptionsGenerateEventExists(d.Get("name").(string), m, jnprSess)
 	if err != nil {
@@ -115,7 +117,7 @@ func resourceEventoptionsGenerateEventCreate(ctx context.Context,
 func resourceEventoptionsGenerateEventRead(ctx context.Context,
 	d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -156,12 +158,14 @@ func resourceEventoptionsGenerateEventUpdate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delEventoptionsGenerateEvent(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -195,12 +199,14 @@ func resourceEventoptionsGenerateEventDelete(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delEventoptionsGenerateEvent(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -218,9 +224,10 @@ func resourceEventoptionsGenerateEventDelete(ctx context.Context,
 	return diagWarns
 }
 
-func resourceEventoptionsGenerateEventImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceEventoptionsGenerateEventImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -31,7 +31,7 @@ func resourceForwardingoptionsSamplingInstance() *schema.Resource {
 		UpdateContext: resourceForwardingoptionsSamplingInstanceUpdate,
 		DeleteContext: resourceForwardingoptionsSamplingInstanceDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceForwardingoptionsSamplingInstanceImport,
+			StateContext: resourceForwardingoptionsSamplingInstanceImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -638,12 +638,14 @@ func resourceForwardingoptionsSamplingInstanceCreate(
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	fwdoptsSamplingInstanceExists, err := checkForwardingoptionsSamplingInstanceExists(d.Get("name").(string), m, jnprSess)
 	if err != nil {
@@ -688,7 +690,7 @@ func resourceForwardingoptionsSamplingInstanceCreate(
 func resourceForwardingoptionsSamplingInstanceRead(ctx context.Context,
 	d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -729,12 +731,14 @@ func resourceForwardingoptionsSamplingInstanceUpdate(
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delForwardingoptionsSamplingInstance(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -769,12 +773,14 @@ func resourceForwardingoptionsSamplingInstanceDelete(
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delForwardingoptionsSamplingInstance(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -792,10 +798,10 @@ func resourceForwardingoptionsSamplingInstanceDelete(
 	return diagWarns
 }
 
-func resourceForwardingoptionsSamplingInstanceImport(d *schema.ResourceData,
+func resourceForwardingoptionsSamplingInstanceImport(ctx context.Context, d *schema.ResourceData,
 	m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,481 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type groupOptions struct {
+	applyGroups bool
+	name        string
+	when        []map[string]interface{}
+}
+
+func resourceGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGroupCreate,
+		ReadContext:   resourceGroupRead,
+		UpdateContext: resourceGroupUpdate,
+		DeleteContext: resourceGroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGroupImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Required:         true,
+				ValidateDiagFunc: validateNameObjectJunos([]string{junosDefaultsGroup}, 64, formatDefAndDots),
+			},
+			"apply_groups": {
+				Type:     schema.TypeBool,
+				Default:  true,
+				Optional: true,
+			},
+			"apply_groups_hierarchies": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"when": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"chassis": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"member": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"model": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"node": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"routing_engine": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"time_start": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"time_end": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							RequiredWith: []string{"when.0.time_start"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	if sess.junosFakeCreateSetFile != "" {
+		if err := setGroup(d, m, nil); err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(d.Get("name").(string))
+
+		return nil
+	}
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
+	var diagWarns diag.Diagnostics
+	groupExists, err := checkGroupExists(d.Get("name").(string), m, jnprSess)
+	if err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	if groupExists {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(fmt.Errorf("group %v already exists", d.Get("name").(string)))...)
+	}
+
+	if err := setGroup(d, m, jnprSess); err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	warns, err := sess.commitConf("create resource junos_group", jnprSess)
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	groupExists, err = checkGroupExists(d.Get("name").(string), m, jnprSess)
+	if err != nil {
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	if groupExists {
+		d.SetId(d.Get("name").(string))
+	} else {
+		return append(diagWarns, diag.FromErr(fmt.Errorf("group %v not exists after commit "+
+			"=> check your config", d.Get("name").(string)))...)
+	}
+
+	return append(diagWarns, resourceGroupReadWJnprSess(d, m, jnprSess)...)
+}
+
+func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+
+	return resourceGroupReadWJnprSess(d, m, jnprSess)
+}
+
+func resourceGroupReadWJnprSess(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
+	mutex.Lock()
+	groupOpts, err := readGroup(d.Get("name").(string), m, jnprSess)
+	if err != nil {
+		mutex.Unlock()
+
+		return diag.FromErr(err)
+	}
+	hierarchies, err := readGroupApplyGroupsHierarchies(d.Get("name").(string),
+		d.Get("apply_groups_hierarchies").([]interface{}), m, jnprSess)
+	mutex.Unlock()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if groupOpts.name == "" {
+		d.SetId("")
+	} else {
+		fillGroupData(d, groupOpts)
+		if tfErr := d.Set("apply_groups_hierarchies", hierarchies); tfErr != nil {
+			panic(tfErr)
+		}
+	}
+
+	return nil
+}
+
+func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	sess := m.(*Session)
+	if sess.junosFakeUpdateAlso {
+		if err := delGroupOpts(d, m, nil); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := setGroup(d, m, nil); err != nil {
+			return diag.FromErr(err)
+		}
+		d.Partial(false)
+
+		return nil
+	}
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
+	var diagWarns diag.Diagnostics
+	if err := delGroupOpts(d, m, jnprSess); err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	if err := setGroup(d, m, jnprSess); err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	warns, err := sess.commitConf("update resource junos_group", jnprSess)
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	d.Partial(false)
+
+	return append(diagWarns, resourceGroupReadWJnprSess(d, m, jnprSess)...)
+}
+
+func resourceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	if sess.junosFakeDeleteAlso {
+		if err := delGroup(d, m, nil); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
+	var diagWarns diag.Diagnostics
+	if err := delGroup(d, m, jnprSess); err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	warns, err := sess.commitConf("delete resource junos_group", jnprSess)
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+
+	return diagWarns
+}
+
+func resourceGroupImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.closeSession(jnprSess)
+	result := make([]*schema.ResourceData, 1)
+	groupExists, err := checkGroupExists(d.Id(), m, jnprSess)
+	if err != nil {
+		return nil, err
+	}
+	if !groupExists {
+		return nil, fmt.Errorf("don't find group with id '%v' (id must be <name>)", d.Id())
+	}
+	groupOpts, err := readGroup(d.Id(), m, jnprSess)
+	if err != nil {
+		return nil, err
+	}
+	fillGroupData(d, groupOpts)
+
+	result[0] = d
+
+	return result, nil
+}
+
+func checkGroupExists(name string, m interface{}, jnprSess *NetconfObject) (bool, error) {
+	sess := m.(*Session)
+	showConfig, err := sess.command("show configuration groups "+name+" | display set", jnprSess)
+	if err != nil {
+		return false, err
+	}
+	if showConfig == emptyWord {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func setGroup(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	sess := m.(*Session)
+	configSet := make([]string, 0)
+	setPrefix := "set groups " + d.Get("name").(string) + " "
+
+	configSet = append(configSet, strings.TrimSpace(setPrefix))
+	for _, v := range d.Get("when").([]interface{}) {
+		if v == nil {
+			return fmt.Errorf("when block is empty")
+		}
+		when := v.(map[string]interface{})
+		if when["chassis"].(string) != "" {
+			configSet = append(configSet, setPrefix+"when chassis "+when["chassis"].(string))
+		}
+		if when["member"].(string) != "" {
+			configSet = append(configSet, setPrefix+"when member "+when["member"].(string))
+		}
+		if when["model"].(string) != "" {
+			configSet = append(configSet, setPrefix+"when model "+when["model"].(string))
+		}
+		if when["node"].(string) != "" {
+			configSet = append(configSet, setPrefix+"when node "+when["node"].(string))
+		}
+		if when["routing_engine"].(string) != "" {
+			configSet = append(configSet, setPrefix+"when routing-engine "+when["routing_engine"].(string))
+		}
+		if when["time_start"].(string) != "" {
+			if when["time_end"].(string) != "" {
+				configSet = append(configSet, setPrefix+"when time "+when["time_start"].(string)+
+					" to "+when["time_end"].(string))
+			} else {
+				configSet = append(configSet, setPrefix+"when time "+when["time_start"].(string))
+			}
+		}
+	}
+	if d.Get("apply_groups").(bool) {
+		configSet = append(configSet, "set apply-groups "+d.Get("name").(string))
+	}
+	for _, v := range d.Get("apply_groups_hierarchies").([]interface{}) {
+		configSet = append(configSet, "set "+v.(string)+" apply-groups "+d.Get("name").(string))
+	}
+
+	return sess.configSet(configSet, jnprSess)
+}
+
+func readGroup(group string, m interface{}, jnprSess *NetconfObject) (groupOptions, error) {
+	sess := m.(*Session)
+	var confRead groupOptions
+
+	showConfig, err := sess.command("show configuration groups "+group+" | display set relative", jnprSess)
+	if err != nil {
+		return confRead, err
+	}
+	if showConfig != emptyWord {
+		confRead.name = group
+		for _, item := range strings.Split(showConfig, "\n") {
+			if strings.Contains(item, "<configuration-output>") {
+				continue
+			}
+			if strings.Contains(item, "</configuration-output>") {
+				break
+			}
+			itemTrim := strings.TrimPrefix(item, setLineStart)
+			if !strings.HasPrefix(itemTrim, "when ") {
+				continue
+			}
+			if len(confRead.when) == 0 {
+				confRead.when = append(confRead.when, map[string]interface{}{
+					"chassis":        "",
+					"member":         "",
+					"model":          "",
+					"node":           "",
+					"routing_engine": "",
+					"time_start":     "",
+					"time_end":       "",
+				})
+			}
+			switch {
+			case strings.HasPrefix(itemTrim, "when chassis "):
+				confRead.when[0]["chassis"] = strings.TrimPrefix(itemTrim, "when chassis ")
+			case strings.HasPrefix(itemTrim, "when member "):
+				confRead.when[0]["member"] = strings.TrimPrefix(itemTrim, "when member ")
+			case strings.HasPrefix(itemTrim, "when model "):
+				confRead.when[0]["model"] = strings.TrimPrefix(itemTrim, "when model ")
+			case strings.HasPrefix(itemTrim, "when node "):
+				confRead.when[0]["node"] = strings.TrimPrefix(itemTrim, "when node ")
+			case strings.HasPrefix(itemTrim, "when routing-engine "):
+				confRead.when[0]["routing_engine"] = strings.TrimPrefix(itemTrim, "when routing-engine ")
+			case strings.HasPrefix(itemTrim, "when time "):
+				timeSplit := strings.Split(strings.TrimPrefix(itemTrim, "when time "), " to ")
+				confRead.when[0]["time_start"] = timeSplit[0]
+				if len(timeSplit) > 1 {
+					confRead.when[0]["time_end"] = timeSplit[1]
+				}
+			}
+		}
+	}
+	showConfigApplyGroups, err := sess.command("show configuration apply-groups | display set relative", jnprSess)
+	if err != nil {
+		return confRead, err
+	}
+	if showConfigApplyGroups != emptyWord {
+		for _, item := range strings.Split(showConfigApplyGroups, "\n") {
+			if strings.Contains(item, "<configuration-output>") {
+				continue
+			}
+			if strings.Contains(item, "</configuration-output>") {
+				break
+			}
+			if strings.TrimSpace(item) == "set "+group {
+				confRead.applyGroups = true
+			}
+		}
+	}
+
+	return confRead, nil
+}
+
+// readGroupApplyGroupsHierarchies returns the hierarchies (in list) where group is in apply-groups.
+func readGroupApplyGroupsHierarchies(
+	group string, hierarchies []interface{}, m interface{}, jnprSess *NetconfObject) ([]string, error) {
+	sess := m.(*Session)
+	result := make([]string, 0, len(hierarchies))
+	for _, v := range hierarchies {
+		showConfig, err := sess.command("show configuration "+v.(string)+" apply-groups | display set relative",
+			jnprSess)
+		if err != nil {
+			return result, err
+		}
+		if showConfig == emptyWord {
+			continue
+		}
+		for _, item := range strings.Split(showConfig, "\n") {
+			if strings.TrimSpace(item) == "set "+group {
+				result = append(result, v.(string))
+
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// delGroupOpts deletes the options of group (not its content) and its apply-groups before an update.
+func delGroupOpts(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	sess := m.(*Session)
+	configSet := []string{
+		"delete groups " + d.Get("name").(string) + " when",
+		"delete apply-groups " + d.Get("name").(string),
+	}
+	oldHierarchies, _ := d.GetChange("apply_groups_hierarchies")
+	for _, v := range oldHierarchies.([]interface{}) {
+		configSet = append(configSet, "delete "+v.(string)+" apply-groups "+d.Get("name").(string))
+	}
+
+	return sess.configSet(configSet, jnprSess)
+}
+
+func delGroup(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	sess := m.(*Session)
+	configSet := []string{
+		"delete groups " + d.Get("name").(string),
+		"delete apply-groups " + d.Get("name").(string),
+	}
+	for _, v := range d.Get("apply_groups_hierarchies").([]interface{}) {
+		configSet = append(configSet, "delete "+v.(string)+" apply-groups "+d.Get("name").(string))
+	}
+
+	return sess.configSet(configSet, jnprSess)
+}
+
+func fillGroupData(d *schema.ResourceData, groupOpts groupOptions) {
+	if tfErr := d.Set("name", groupOpts.name); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("apply_groups", groupOpts.applyGroups); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("when", groupOpts.when); tfErr != nil {
+		panic(tfErr)
+	}
+}
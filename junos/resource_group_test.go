@@ -0,0 +1,76 @@
+package junos_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccJunosGroup_basic(t *testing.T) {
+	if os.Getenv("TESTACC_SWITCH") == "" {
+		resource.Test(t, resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccJunosGroupConfigCreate(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("junos_group.testacc_group",
+							"apply_groups", "true"),
+						resource.TestCheckResourceAttr("junos_group.testacc_group",
+							"when.#", "1"),
+						resource.TestCheckResourceAttr("junos_group.testacc_group",
+							"when.0.time_start", "08:00"),
+						resource.TestCheckResourceAttr("junos_routing_instance.testacc_group",
+							"config_group", "testacc_group"),
+					),
+				},
+				{
+					Config: testAccJunosGroupConfigUpdate(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("junos_group.testacc_group",
+							"apply_groups_hierarchies.#", "1"),
+						resource.TestCheckResourceAttr("junos_group.testacc_group",
+							"when.#", "0"),
+					),
+				},
+				{
+					ResourceName:            "junos_group.testacc_group",
+					ImportState:             true,
+					ImportStateVerify:       true,
+					ImportStateVerifyIgnore: []string{"apply_groups_hierarchies"},
+				},
+			},
+		})
+	}
+}
+
+func testAccJunosGroupConfigCreate() string {
+	return `
+resource junos_group "testacc_group" {
+  name = "testacc_group"
+  when {
+    time_start = "08:00"
+    time_end   = "18:00"
+  }
+}
+resource junos_routing_instance "testacc_group" {
+  name         = "testacc_group"
+  config_group = junos_group.testacc_group.name
+}
+`
+}
+
+func testAccJunosGroupConfigUpdate() string {
+	return `
+resource junos_group "testacc_group" {
+  name                     = "testacc_group"
+  apply_groups_hierarchies = ["routing-options"]
+}
+resource junos_routing_instance "testacc_group" {
+  name         = "testacc_group"
+  config_group = junos_group.testacc_group.name
+}
+`
+}
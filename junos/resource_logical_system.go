@@ -0,0 +1,421 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	bchk "github.com/jeremmfr/go-utils/basiccheck"
+)
+
+type logicalSystemOptions struct {
+	name            string
+	securityProfile []map[string]interface{}
+}
+
+func resourceLogicalSystem() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLogicalSystemCreate,
+		ReadContext:   resourceLogicalSystemRead,
+		UpdateContext: resourceLogicalSystemUpdate,
+		DeleteContext: resourceLogicalSystemDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceLogicalSystemImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Required:         true,
+				ValidateDiagFunc: validateNameObjectJunos([]string{}, 64, formatDefault),
+			},
+			"security_profile": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateDiagFunc: validateNameObjectJunos([]string{}, 64, formatDefault),
+						},
+						"resource": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"type": {
+										Type:     schema.TypeString,
+										Required: true,
+										ValidateFunc: validation.StringMatch(regexp.MustCompile(
+											`^[a-z][a-z0-9-]*$`), "must be a resource type of security profile (like 'policy')"),
+									},
+									"maximum": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      -1,
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+									"reserved": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      -1,
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceLogicalSystemCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	if sess.junosFakeCreateSetFile != "" {
+		if err := setLogicalSystem(d, m, nil); err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(d.Get("name").(string))
+
+		return nil
+	}
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
+	var diagWarns diag.Diagnostics
+	logicalSystemExists, err := checkLogicalSystemExists(d.Get("name").(string), m, jnprSess)
+	if err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	if logicalSystemExists {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns,
+			diag.FromErr(fmt.Errorf("logical system %v already exists", d.Get("name").(string)))...)
+	}
+
+	if err := setLogicalSystem(d, m, jnprSess); err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	warns, err := sess.commitConf("create resource junos_logical_system", jnprSess)
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	logicalSystemExists, err = checkLogicalSystemExists(d.Get("name").(string), m, jnprSess)
+	if err != nil {
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	if logicalSystemExists {
+		d.SetId(d.Get("name").(string))
+	} else {
+		return append(diagWarns, diag.FromErr(fmt.Errorf("logical system %v not exists after commit "+
+			"=> check your config", d.Get("name").(string)))...)
+	}
+
+	return append(diagWarns, resourceLogicalSystemReadWJnprSess(d, m, jnprSess)...)
+}
+
+func resourceLogicalSystemRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+
+	return resourceLogicalSystemReadWJnprSess(d, m, jnprSess)
+}
+
+func resourceLogicalSystemReadWJnprSess(
+	d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) diag.Diagnostics {
+	mutex.Lock()
+	logicalSystemOpts, err := readLogicalSystem(d.Get("name").(string), m, jnprSess)
+	mutex.Unlock()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if logicalSystemOpts.name == "" {
+		d.SetId("")
+	} else {
+		fillLogicalSystemData(d, logicalSystemOpts)
+	}
+
+	return nil
+}
+
+func resourceLogicalSystemUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.Partial(true)
+	sess := m.(*Session)
+	if sess.junosFakeUpdateAlso {
+		if err := delLogicalSystemSecurityProfile(d, m, nil); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := setLogicalSystem(d, m, nil); err != nil {
+			return diag.FromErr(err)
+		}
+		d.Partial(false)
+
+		return nil
+	}
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
+	var diagWarns diag.Diagnostics
+	if err := delLogicalSystemSecurityProfile(d, m, jnprSess); err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	if err := setLogicalSystem(d, m, jnprSess); err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	warns, err := sess.commitConf("update resource junos_logical_system", jnprSess)
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	d.Partial(false)
+
+	return append(diagWarns, resourceLogicalSystemReadWJnprSess(d, m, jnprSess)...)
+}
+
+func resourceLogicalSystemDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sess := m.(*Session)
+	if sess.junosFakeDeleteAlso {
+		if err := delLogicalSystem(d, m, nil); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer sess.closeSession(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
+	var diagWarns diag.Diagnostics
+	if err := delLogicalSystem(d, m, jnprSess); err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+	warns, err := sess.commitConf("delete resource junos_logical_system", jnprSess)
+	appendDiagWarns(&diagWarns, warns)
+	if err != nil {
+		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
+
+		return append(diagWarns, diag.FromErr(err)...)
+	}
+
+	return diagWarns
+}
+
+func resourceLogicalSystemImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	sess := m.(*Session)
+	jnprSess, err := sess.startNewSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer sess.closeSession(jnprSess)
+	result := make([]*schema.ResourceData, 1)
+	logicalSystemExists, err := checkLogicalSystemExists(d.Id(), m, jnprSess)
+	if err != nil {
+		return nil, err
+	}
+	if !logicalSystemExists {
+		return nil, fmt.Errorf("don't find logical system with id '%v' (id must be <name>)", d.Id())
+	}
+	logicalSystemOpts, err := readLogicalSystem(d.Id(), m, jnprSess)
+	if err != nil {
+		return nil, err
+	}
+	fillLogicalSystemData(d, logicalSystemOpts)
+
+	result[0] = d
+
+	return result, nil
+}
+
+func checkLogicalSystemExists(logicalSystem string, m interface{}, jnprSess *NetconfObject) (bool, error) {
+	sess := m.(*Session)
+	showConfig, err := sess.command("show configuration logical-systems "+logicalSystem+" | display set", jnprSess)
+	if err != nil {
+		return false, err
+	}
+	if showConfig == emptyWord {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func setLogicalSystem(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	sess := m.(*Session)
+	configSet := make([]string, 0)
+
+	configSet = append(configSet, "set logical-systems "+d.Get("name").(string))
+	for _, v := range d.Get("security_profile").([]interface{}) {
+		securityProfile := v.(map[string]interface{})
+		setPrefix := "set system security-profile " + securityProfile["name"].(string) + " "
+		configSet = append(configSet, setPrefix+"logical-system "+d.Get("name").(string))
+		resourceTypeList := make([]string, 0)
+		for _, r := range securityProfile["resource"].([]interface{}) {
+			resource := r.(map[string]interface{})
+			if bchk.StringInSlice(resource["type"].(string), resourceTypeList) {
+				return fmt.Errorf("multiple blocks resource with the same type %s", resource["type"].(string))
+			}
+			resourceTypeList = append(resourceTypeList, resource["type"].(string))
+			if resource["maximum"].(int) == -1 && resource["reserved"].(int) == -1 {
+				return fmt.Errorf("maximum or reserved need to be set in resource block with type %s",
+					resource["type"].(string))
+			}
+			if v := resource["maximum"].(int); v != -1 {
+				configSet = append(configSet, setPrefix+resource["type"].(string)+" maximum "+strconv.Itoa(v))
+			}
+			if v := resource["reserved"].(int); v != -1 {
+				configSet = append(configSet, setPrefix+resource["type"].(string)+" reserved "+strconv.Itoa(v))
+			}
+		}
+	}
+
+	return sess.configSet(configSet, jnprSess)
+}
+
+func readLogicalSystem(logicalSystem string, m interface{}, jnprSess *NetconfObject) (logicalSystemOptions, error) {
+	sess := m.(*Session)
+	var confRead logicalSystemOptions
+
+	logicalSystemExists, err := checkLogicalSystemExists(logicalSystem, m, jnprSess)
+	if err != nil {
+		return confRead, err
+	}
+	if !logicalSystemExists {
+		return confRead, nil
+	}
+	confRead.name = logicalSystem
+	showConfig, err := sess.command("show configuration system security-profile | display set relative", jnprSess)
+	if err != nil {
+		return confRead, err
+	}
+	if showConfig == emptyWord {
+		return confRead, nil
+	}
+	lines := strings.Split(showConfig, "\n")
+	for _, item := range lines {
+		itemTrim := strings.TrimPrefix(item, setLineStart)
+		if itemSplit := strings.Split(itemTrim, " "); len(itemSplit) == 3 &&
+			itemSplit[1] == "logical-system" && itemSplit[2] == logicalSystem {
+			confRead.securityProfile = append(confRead.securityProfile, map[string]interface{}{
+				"name":     itemSplit[0],
+				"resource": make([]map[string]interface{}, 0),
+			})
+
+			break
+		}
+	}
+	if len(confRead.securityProfile) == 0 {
+		return confRead, nil
+	}
+	profilePrefix := confRead.securityProfile[0]["name"].(string) + " "
+	for _, item := range lines {
+		itemTrim := strings.TrimPrefix(item, setLineStart)
+		if !strings.HasPrefix(itemTrim, profilePrefix) {
+			continue
+		}
+		itemSplit := strings.Split(strings.TrimPrefix(itemTrim, profilePrefix), " ")
+		if len(itemSplit) != 3 || (itemSplit[1] != "maximum" && itemSplit[1] != "reserved") {
+			continue
+		}
+		value, err := strconv.Atoi(itemSplit[2])
+		if err != nil {
+			return confRead, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemSplit[2], err)
+		}
+		resources := confRead.securityProfile[0]["resource"].([]map[string]interface{})
+		var resource map[string]interface{}
+		for _, r := range resources {
+			if r["type"].(string) == itemSplit[0] {
+				resource = r
+			}
+		}
+		if resource == nil {
+			resource = map[string]interface{}{
+				"type":     itemSplit[0],
+				"maximum":  -1,
+				"reserved": -1,
+			}
+			confRead.securityProfile[0]["resource"] = append(resources, resource)
+		}
+		resource[itemSplit[1]] = value
+	}
+
+	return confRead, nil
+}
+
+// delLogicalSystemSecurityProfile deletes the previous security profile of logical system before an update.
+func delLogicalSystemSecurityProfile(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	sess := m.(*Session)
+	configSet := make([]string, 0, 1)
+	oldSecurityProfile, _ := d.GetChange("security_profile")
+	for _, v := range oldSecurityProfile.([]interface{}) {
+		configSet = append(configSet, "delete system security-profile "+v.(map[string]interface{})["name"].(string))
+	}
+	if len(configSet) == 0 {
+		return nil
+	}
+
+	return sess.configSet(configSet, jnprSess)
+}
+
+func delLogicalSystem(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
+	sess := m.(*Session)
+	configSet := make([]string, 0, 2)
+	configSet = append(configSet, "delete logical-systems "+d.Get("name").(string))
+	for _, v := range d.Get("security_profile").([]interface{}) {
+		configSet = append(configSet, "delete system security-profile "+v.(map[string]interface{})["name"].(string))
+	}
+
+	return sess.configSet(configSet, jnprSess)
+}
+
+func fillLogicalSystemData(d *schema.ResourceData, logicalSystemOpts logicalSystemOptions) {
+	if tfErr := d.Set("name", logicalSystemOpts.name); tfErr != nil {
+		panic(tfErr)
+	}
+	if tfErr := d.Set("security_profile", logicalSystemOpts.securityProfile); tfErr != nil {
+		panic(tfErr)
+	}
+}
@@ -0,0 +1,89 @@
+package junos_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccJunosLogicalSystem_basic(t *testing.T) {
+	if os.Getenv("TESTACC_SWITCH") == "" {
+		resource.Test(t, resource.TestCase{
+			PreCheck:  func() { testAccPreCheck(t) },
+			Providers: testAccProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: testAccJunosLogicalSystemConfigCreate(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("junos_logical_system.testacc_lsys",
+							"security_profile.#", "1"),
+						resource.TestCheckResourceAttr("junos_logical_system.testacc_lsys",
+							"security_profile.0.resource.#", "1"),
+						resource.TestCheckResourceAttr("junos_logical_system.testacc_lsys",
+							"security_profile.0.resource.0.maximum", "100"),
+						resource.TestCheckResourceAttr("junos_routing_instance.testacc_lsys",
+							"logical_system", "testacc_lsys"),
+					),
+				},
+				{
+					Config: testAccJunosLogicalSystemConfigUpdate(),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("junos_logical_system.testacc_lsys",
+							"security_profile.0.name", "testacc_lsys2"),
+						resource.TestCheckResourceAttr("junos_logical_system.testacc_lsys",
+							"security_profile.0.resource.#", "2"),
+					),
+				},
+				{
+					ResourceName:      "junos_logical_system.testacc_lsys",
+					ImportState:       true,
+					ImportStateVerify: true,
+				},
+			},
+		})
+	}
+}
+
+func testAccJunosLogicalSystemConfigCreate() string {
+	return `
+resource junos_logical_system "testacc_lsys" {
+  name = "testacc_lsys"
+  security_profile {
+    name = "testacc_lsys"
+    resource {
+      type     = "policy"
+      maximum  = 100
+      reserved = 10
+    }
+  }
+}
+resource junos_routing_instance "testacc_lsys" {
+  name           = "testacc_lsys"
+  logical_system = junos_logical_system.testacc_lsys.name
+}
+`
+}
+
+func testAccJunosLogicalSystemConfigUpdate() string {
+	return `
+resource junos_logical_system "testacc_lsys" {
+  name = "testacc_lsys"
+  security_profile {
+    name = "testacc_lsys2"
+    resource {
+      type    = "policy"
+      maximum = 200
+    }
+    resource {
+      type     = "zone"
+      reserved = 5
+    }
+  }
+}
+resource junos_routing_instance "testacc_lsys" {
+  name           = "testacc_lsys"
+  logical_system = junos_logical_system.testacc_lsys.name
+}
+`
+}
@@ -0,0 +1,51 @@
+package junos
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestFakeDeviceResourceRoutingInstance(t *testing.T) {
+	sess, device := newFakeDeviceSession(t, "vsrx")
+	ctx := context.Background()
+	res := resourceRoutingInstance()
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"name":        "test",
+		"description": "test instance",
+	})
+	if diags := res.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "test" {
+		t.Errorf("unexpected id %q after create", d.Id())
+	}
+	committed := strings.Join(device.Committed(), "\n")
+	if !strings.Contains(committed, "set routing-instances test instance-type virtual-router") {
+		t.Errorf("instance-type not committed in configuration:\n%s", committed)
+	}
+	if commits := device.Commits(); len(commits) != 1 || commits[0] != "create resource junos_routing_instance" {
+		t.Errorf("unexpected commits %q", commits)
+	}
+
+	read := res.Data(nil)
+	read.SetId("test")
+	if err := read.Set("name", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if diags := res.ReadContext(ctx, read, sess); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if v := read.Get("description").(string); v != "test instance" {
+		t.Errorf("unexpected description %q after read", v)
+	}
+
+	if diags := res.DeleteContext(ctx, read, sess); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if committed := device.Committed(); len(committed) != 0 {
+		t.Errorf("configuration not empty after delete: %q", committed)
+	}
+}
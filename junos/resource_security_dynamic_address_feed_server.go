@@ -28,7 +28,7 @@ func resourceSecurityDynamicAddressFeedServer() *schema.Resource {
 		UpdateContext: resourceSecurityDynamicAddressFeedServerUpdate,
 		DeleteContext: resourceSecurityDynamicAddressFeedServerDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceSecurityDynamicAddressFeedServerImport,
+			StateContext: resourceSecurityDynamicAddressFeedServerImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -103,7 +103,7 @@ func resourceSecurityDynamicAddressFeedServerCreate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -112,7 +112,9 @@ func resourceSecurityDynamicAddressFeedServerCreate(ctx context.Context,
 		return diag.FromErr(fmt.Errorf("security dynamic-address feed-server "+
 			"not compatible with Junos device %s", jnprSess.SystemInformation.HardwareModel))
 	}
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	securityDynamicAddressFeedServerExists, err := checkSecurityDynamicAddressFeedServersExists(
 		d.Get("name").(string), m, jnprSess)
@@ -158,7 +160,7 @@ func resourceSecurityDynamicAddressFeedServerCreate(ctx context.Context,
 func resourceSecurityDynamicAddressFeedServerRead(ctx context.Context,
 	d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -199,12 +201,14 @@ func resourceSecurityDynamicAddressFeedServerUpdate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delSecurityDynamicAddressFeedServer(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -238,12 +242,14 @@ func resourceSecurityDynamicAddressFeedServerDelete(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delSecurityDynamicAddressFeedServer(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -262,9 +268,9 @@ func resourceSecurityDynamicAddressFeedServerDelete(ctx context.Context,
 }
 
 func resourceSecurityDynamicAddressFeedServerImport(
-	d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -25,7 +25,7 @@ func resourceSecurityDynamicAddressName() *schema.Resource {
 		UpdateContext: resourceSecurityDynamicAddressNameUpdate,
 		DeleteContext: resourceSecurityDynamicAddressNameDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceSecurityDynamicAddressNameImport,
+			StateContext: resourceSecurityDynamicAddressNameImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -99,7 +99,7 @@ func resourceSecurityDynamicAddressNameCreate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -108,7 +108,9 @@ func resourceSecurityDynamicAddressNameCreate(ctx context.Context,
 		return diag.FromErr(fmt.Errorf("security dynamic-address address-name "+
 			"not compatible with Junos device %s", jnprSess.SystemInformation.HardwareModel))
 	}
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	securityDynamicAddressNameExists, err := checkSecurityDynamicAddressNamesExists(d.Get("name").(string), m, jnprSess)
 	if err != nil {
@@ -152,7 +154,7 @@ func resourceSecurityDynamicAddressNameCreate(ctx context.Context,
 func resourceSecurityDynamicAddressNameRead(ctx context.Context,
 	d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -193,12 +195,14 @@ func resourceSecurityDynamicAddressNameUpdate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delSecurityDynamicAddressName(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -232,12 +236,14 @@ func resourceSecurityDynamicAddressNameDelete(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delSecurityDynamicAddressName(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -256,9 +262,9 @@ func resourceSecurityDynamicAddressNameDelete(ctx context.Context,
 }
 
 func resourceSecurityDynamicAddressNameImport(
-	d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
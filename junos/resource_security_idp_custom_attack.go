@@ -31,7 +31,7 @@ func resourceSecurityIdpCustomAttack() *schema.Resource {
 		UpdateContext: resourceSecurityIdpCustomAttackUpdate,
 		DeleteContext: resourceSecurityIdpCustomAttackDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceSecurityIdpCustomAttackImport,
+			StateContext: resourceSecurityIdpCustomAttackImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -826,7 +826,7 @@ func resourceSecurityIdpCustomAttackCreate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -835,7 +835,9 @@ func resourceSecurityIdpCustomAttackCreate(ctx context.Context,
 		return diag.FromErr(fmt.Errorf("security idp custom-attack not compatible with Junos device %s",
 			jnprSess.SystemInformation.HardwareModel))
 	}
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	idpCustomAttackExists, err := checkSecurityIdpCustomAttackExists(d.Get("name").(string), m, jnprSess)
 	if err != nil {
@@ -877,7 +879,7 @@ func resourceSecurityIdpCustomAttackCreate(ctx context.Context,
 
 func resourceSecurityIdpCustomAttackRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -918,12 +920,14 @@ func resourceSecurityIdpCustomAttackUpdate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delSecurityIdpCustomAttack(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -957,12 +961,14 @@ func resourceSecurityIdpCustomAttackDelete(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delSecurityIdpCustomAttack(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -980,9 +986,10 @@ func resourceSecurityIdpCustomAttackDelete(ctx context.Context,
 	return diagWarns
 }
 
-func resourceSecurityIdpCustomAttackImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceSecurityIdpCustomAttackImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
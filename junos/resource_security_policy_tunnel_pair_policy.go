@@ -22,7 +22,7 @@ func resourceSecurityPolicyTunnelPairPolicy() *schema.Resource {
 		ReadContext:   resourceSecurityPolicyTunnelPairPolicyRead,
 		DeleteContext: resourceSecurityPolicyTunnelPairPolicyDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceSecurityPolicyTunnelPairPolicyImport,
+			StateContext: resourceSecurityPolicyTunnelPairPolicyImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"zone_a": {
@@ -65,7 +65,7 @@ func resourceSecurityPolicyTunnelPairPolicyCreate(
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -74,7 +74,9 @@ func resourceSecurityPolicyTunnelPairPolicyCreate(
 		return diag.FromErr(fmt.Errorf("security policy tunnel pair policy not compatible with Junos device %s",
 			jnprSess.SystemInformation.HardwareModel))
 	}
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	securityPolicyExists, err := checkSecurityPolicyExists(d.Get("zone_a").(string), d.Get("zone_b").(string), m, jnprSess)
 	if err != nil {
@@ -145,7 +147,7 @@ func resourceSecurityPolicyTunnelPairPolicyCreate(
 func resourceSecurityPolicyTunnelPairPolicyRead(
 	ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -184,12 +186,14 @@ func resourceSecurityPolicyTunnelPairPolicyDelete(
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delSecurityPolicyTunnelPairPolicy(d, m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -207,10 +211,10 @@ func resourceSecurityPolicyTunnelPairPolicyDelete(
 	return diagWarns
 }
 
-func resourceSecurityPolicyTunnelPairPolicyImport(d *schema.ResourceData,
+func resourceSecurityPolicyTunnelPairPolicyImport(ctx context.Context, d *schema.ResourceData,
 	m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -247,13 +251,13 @@ func checkSecurityPolicyPairExists(zoneA, policyAtoB, zoneB, policyBtoA string,
 
 	showConfigPairAtoB, err := sess.command("show configuration"+
 		" security policies from-zone "+zoneA+" to-zone "+zoneB+" policy "+policyAtoB+
-		" then permit tunnel pair-policy | display set", jnprSess)
+		" then permit tunnel | display set relative", jnprSess)
 	if err != nil {
 		return false, err
 	}
 	showConfigPairBtoA, err := sess.command("show configuration"+
 		" security policies from-zone "+zoneB+" to-zone "+zoneA+" policy "+policyBtoA+
-		" then permit tunnel pair-policy | display set", jnprSess)
+		" then permit tunnel | display set relative", jnprSess)
 	if err != nil {
 		return false, err
 	}
@@ -293,7 +297,7 @@ func readSecurityPolicyTunnelPairPolicy(idRessource string,
 
 	showConfig, err := sess.command("show configuration"+
 		" security policies from-zone "+zoneA+" to-zone "+zoneB+" policy "+policyAtoB+
-		" then permit tunnel pair-policy | display set", jnprSess)
+		" then permit tunnel | display set relative", jnprSess)
 	if err != nil {
 		return confRead, err
 	}
@@ -307,16 +311,14 @@ func readSecurityPolicyTunnelPairPolicy(idRessource string,
 			if strings.Contains(item, "</configuration-output>") {
 				break
 			}
-			if strings.Contains(item, " tunnel pair-policy ") {
-				confRead.policyBtoA = strings.TrimPrefix(item,
-					"set security policies from-zone "+zoneA+" to-zone "+zoneB+
-						" policy "+policyAtoB+" then permit tunnel pair-policy ")
+			if strings.HasPrefix(item, "set pair-policy ") {
+				confRead.policyBtoA = strings.TrimPrefix(item, "set pair-policy ")
 			}
 		}
 	}
 	showConfig, err = sess.command("show configuration"+
 		" security policies from-zone "+zoneB+" to-zone "+zoneA+" policy "+policyBtoA+
-		" then permit tunnel pair-policy | display set", jnprSess)
+		" then permit tunnel | display set relative", jnprSess)
 	if err != nil {
 		return confRead, err
 	}
@@ -330,10 +332,8 @@ func readSecurityPolicyTunnelPairPolicy(idRessource string,
 			if strings.Contains(item, "</configuration-output>") {
 				break
 			}
-			if strings.Contains(item, " tunnel pair-policy ") {
-				confRead.policyAtoB = strings.TrimPrefix(item,
-					"set security policies from-zone "+zoneB+" to-zone "+zoneA+
-						" policy "+policyBtoA+" then permit tunnel pair-policy ")
+			if strings.HasPrefix(item, "set pair-policy ") {
+				confRead.policyAtoB = strings.TrimPrefix(item, "set pair-policy ")
 			}
 		}
 	}
@@ -33,7 +33,7 @@ func resourceSecurityUtmProfileWebFilteringEnhanced() *schema.Resource {
 		UpdateContext: resourceSecurityUtmProfileWebFilteringEnhancedUpdate,
 		DeleteContext: resourceSecurityUtmProfileWebFilteringEnhancedDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceSecurityUtmProfileWebFilteringEnhancedImport,
+			StateContext: resourceSecurityUtmProfileWebFilteringEnhancedImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -199,7 +199,7 @@ func resourceSecurityUtmProfileWebFilteringEnhancedCreate(
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -208,7 +208,9 @@ func resourceSecurityUtmProfileWebFilteringEnhancedCreate(
 		return diag.FromErr(fmt.Errorf("security utm feature-profile web-filtering juniper-enhanced "+
 			"not compatible with Junos device %s", jnprSess.SystemInformation.HardwareModel))
 	}
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	utmProfileWebFEnhancedExists, err := checkUtmProfileWebFEnhancedExists(d.Get("name").(string), m, jnprSess)
 	if err != nil {
@@ -252,7 +254,7 @@ func resourceSecurityUtmProfileWebFilteringEnhancedCreate(
 func resourceSecurityUtmProfileWebFilteringEnhancedRead(
 	ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -293,12 +295,14 @@ func resourceSecurityUtmProfileWebFilteringEnhancedUpdate(
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delUtmProfileWebFEnhanced(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -332,12 +336,14 @@ func resourceSecurityUtmProfileWebFilteringEnhancedDelete(
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delUtmProfileWebFEnhanced(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -356,9 +362,9 @@ func resourceSecurityUtmProfileWebFilteringEnhancedDelete(
 }
 
 func resourceSecurityUtmProfileWebFilteringEnhancedImport(
-	d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -28,7 +28,7 @@ func resourceServices() *schema.Resource {
 		UpdateContext: resourceServicesUpdate,
 		DeleteContext: resourceServicesDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceServicesImport,
+			StateContext: resourceServicesImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"clean_on_destroy": {
@@ -624,12 +624,14 @@ func resourceServicesCreate(ctx context.Context, d *schema.ResourceData, m inter
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := setServices(d, m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -650,7 +652,7 @@ func resourceServicesCreate(ctx context.Context, d *schema.ResourceData, m inter
 
 func resourceServicesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -685,12 +687,14 @@ func resourceServicesUpdate(ctx context.Context, d *schema.ResourceData, m inter
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delServices(d, m, jnprSess, false); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -724,12 +728,14 @@ func resourceServicesDelete(ctx context.Context, d *schema.ResourceData, m inter
 
 			return nil
 		}
-		jnprSess, err := sess.startNewSession()
+		jnprSess, err := sess.startNewSession(ctx)
 		if err != nil {
 			return diag.FromErr(err)
 		}
 		defer sess.closeSession(jnprSess)
-		sess.configLock(jnprSess)
+		if err := sess.configLock(jnprSess); err != nil {
+			return diag.FromErr(err)
+		}
 		var diagWarns diag.Diagnostics
 		if err := delServices(d, m, jnprSess, true); err != nil {
 			appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -748,9 +754,10 @@ func resourceServicesDelete(ctx context.Context, d *schema.ResourceData, m inter
 	return nil
 }
 
-func resourceServicesImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceServicesImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
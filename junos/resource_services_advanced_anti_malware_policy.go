@@ -38,7 +38,7 @@ func resourceServicesAdvancedAntiMalwarePolicy() *schema.Resource {
 		UpdateContext: resourceServicesAdvancedAntiMalwarePolicyUpdate,
 		DeleteContext: resourceServicesAdvancedAntiMalwarePolicyDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceServicesAdvancedAntiMalwarePolicyImport,
+			StateContext: resourceServicesAdvancedAntiMalwarePolicyImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -147,12 +147,14 @@ func resourceServicesAdvancedAntiMalwarePolicyCreate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	svcAdvancedAntiMalwarePolicyExists, err := checkServicesAdvancedAntiMalwarePolicyExists(
 		d.Get("name").(string), m, jnprSess)
@@ -200,7 +202,7 @@ func resourceServicesAdvancedAntiMalwarePolicyCreate(ctx context.Context,
 func resourceServicesAdvancedAntiMalwarePolicyRead(ctx context.Context,
 	d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -241,12 +243,14 @@ func resourceServicesAdvancedAntiMalwarePolicyUpdate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delServicesAdvancedAntiMalwarePolicy(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -280,12 +284,14 @@ func resourceServicesAdvancedAntiMalwarePolicyDelete(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delServicesAdvancedAntiMalwarePolicy(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -304,9 +310,9 @@ func resourceServicesAdvancedAntiMalwarePolicyDelete(ctx context.Context,
 }
 
 func resourceServicesAdvancedAntiMalwarePolicyImport(
-	d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
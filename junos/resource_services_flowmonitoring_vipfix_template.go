@@ -37,7 +37,7 @@ func resourceServicesFlowMonitoringVIPFixTemplate() *schema.Resource {
 		UpdateContext: resourceServicesFlowMonitoringVIPFixTemplateUpdate,
 		DeleteContext: resourceServicesFlowMonitoringVIPFixTemplateDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceServicesFlowMonitoringVIPFixTemplateImport,
+			StateContext: resourceServicesFlowMonitoringVIPFixTemplateImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -141,12 +141,14 @@ func resourceServicesFlowMonitoringVIPFixTemplateCreate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	flowMonitoringVIPFixTemplateExists, err := checkServicesFlowMonitoringVIPFixTemplateExists(
 		d.Get("name").(string), m, jnprSess)
@@ -193,7 +195,7 @@ func resourceServicesFlowMonitoringVIPFixTemplateCreate(ctx context.Context,
 func resourceServicesFlowMonitoringVIPFixTemplateRead(ctx context.Context,
 	d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -235,12 +237,14 @@ func resourceServicesFlowMonitoringVIPFixTemplateUpdate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delServicesFlowMonitoringVIPFixTemplate(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -274,12 +278,14 @@ func resourceServicesFlowMonitoringVIPFixTemplateDelete(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delServicesFlowMonitoringVIPFixTemplate(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -298,9 +304,9 @@ func resourceServicesFlowMonitoringVIPFixTemplateDelete(ctx context.Context,
 }
 
 func resourceServicesFlowMonitoringVIPFixTemplateImport(
-	d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
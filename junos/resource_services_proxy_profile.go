@@ -24,7 +24,7 @@ func resourceServicesProxyProfile() *schema.Resource {
 		UpdateContext: resourceServicesProxyProfileUpdate,
 		DeleteContext: resourceServicesProxyProfileDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceServicesProxyProfileImport,
+			StateContext: resourceServicesProxyProfileImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -56,12 +56,14 @@ func resourceServicesProxyProfileCreate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	proxyProfileExists, err := checkServicesProxyProfileExists(d.Get("name").(string), m, jnprSess)
 	if err != nil {
@@ -105,7 +107,7 @@ func resourceServicesProxyProfileCreate(ctx context.Context,
 func resourceServicesProxyProfileRead(ctx context.Context,
 	d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -146,12 +148,14 @@ func resourceServicesProxyProfileUpdate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delServicesProxyProfile(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -185,12 +189,14 @@ func resourceServicesProxyProfileDelete(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delServicesProxyProfile(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -209,9 +215,9 @@ func resourceServicesProxyProfileDelete(ctx context.Context,
 }
 
 func resourceServicesProxyProfileImport(
-	d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
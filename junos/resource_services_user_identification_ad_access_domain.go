@@ -29,7 +29,7 @@ func resourceServicesUserIdentAdAccessDomain() *schema.Resource {
 		UpdateContext: resourceServicesUserIdentAdAccessDomainUpdate,
 		DeleteContext: resourceServicesUserIdentAdAccessDomainDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceServicesUserIdentAdAccessDomainImport,
+			StateContext: resourceServicesUserIdentAdAccessDomainImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -136,12 +136,14 @@ func resourceServicesUserIdentAdAccessDomainCreate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	svcUserIdentAdAccessDomainExists, err := checkServicesUserIdentAdAccessDomainExists(
 		d.Get("name").(string), m, jnprSess)
@@ -188,7 +190,7 @@ func resourceServicesUserIdentAdAccessDomainCreate(ctx context.Context,
 func resourceServicesUserIdentAdAccessDomainRead(ctx context.Context,
 	d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -229,12 +231,14 @@ func resourceServicesUserIdentAdAccessDomainUpdate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delServicesUserIdentAdAccessDomain(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -268,12 +272,14 @@ func resourceServicesUserIdentAdAccessDomainDelete(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delServicesUserIdentAdAccessDomain(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -292,9 +298,9 @@ func resourceServicesUserIdentAdAccessDomainDelete(ctx context.Context,
 }
 
 func resourceServicesUserIdentAdAccessDomainImport(
-	d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -23,7 +23,7 @@ func resourceServicesUserIdentDeviceIdentityProfile() *schema.Resource {
 		UpdateContext: resourceServicesUserIdentDeviceIdentityProfileUpdate,
 		DeleteContext: resourceServicesUserIdentDeviceIdentityProfileDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceServicesUserIdentDeviceIdentityProfileImport,
+			StateContext: resourceServicesUserIdentDeviceIdentityProfileImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -72,12 +72,14 @@ func resourceServicesUserIdentDeviceIdentityProfileCreate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	svcUserIdentDevIdentProfileExists, err := checkServicesUserIdentDeviceIdentityProfileExists(
 		d.Get("name").(string), m, jnprSess)
@@ -125,7 +127,7 @@ func resourceServicesUserIdentDeviceIdentityProfileCreate(ctx context.Context,
 func resourceServicesUserIdentDeviceIdentityProfileRead(ctx context.Context,
 	d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -167,12 +169,14 @@ func resourceServicesUserIdentDeviceIdentityProfileUpdate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delServicesUserIdentDeviceIdentityProfile(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -207,12 +211,14 @@ func resourceServicesUserIdentDeviceIdentityProfileDelete(ctx context.Context,
 		return nil
 	}
 
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delServicesUserIdentDeviceIdentityProfile(d.Get("name").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -231,9 +237,9 @@ func resourceServicesUserIdentDeviceIdentityProfileDelete(ctx context.Context,
 }
 
 func resourceServicesUserIdentDeviceIdentityProfileImport(
-	d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
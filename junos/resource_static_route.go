@@ -41,6 +41,43 @@ type staticRouteOptions struct {
 	qualifiedNextHop         []map[string]interface{}
 }
 
+// staticRouteXML is the configuration of a static route read with get-configuration.
+type staticRouteXML struct {
+	Active configXMLFlag `xml:"active"`
+	AsPath struct {
+		Aggregator struct {
+			AsNumber string `xml:"as-number"`
+			Address  string `xml:"address"`
+		} `xml:"aggregator"`
+		AtomicAggregate configXMLFlag `xml:"atomic-aggregate"`
+		Origin          string        `xml:"origin"`
+		Path            string        `xml:"path"`
+	} `xml:"as-path"`
+	Community        []string      `xml:"community"`
+	Discard          configXMLFlag `xml:"discard"`
+	Install          configXMLFlag `xml:"install"`
+	NoInstall        configXMLFlag `xml:"no-install"`
+	Metric           configXMLInt  `xml:"metric"`
+	NextHop          []string      `xml:"next-hop"`
+	NextTable        string        `xml:"next-table"`
+	Passive          configXMLFlag `xml:"passive"`
+	Preference       configXMLInt  `xml:"preference"`
+	QualifiedNextHop []struct {
+		Name       string       `xml:"name"`
+		Interface  string       `xml:"interface"`
+		Metric     configXMLInt `xml:"metric"`
+		Preference configXMLInt `xml:"preference"`
+	} `xml:"qualified-next-hop"`
+	Readvertise   configXMLFlag `xml:"readvertise"`
+	NoReadvertise configXMLFlag `xml:"no-readvertise"`
+	Receive       configXMLFlag `xml:"receive"`
+	Reject        configXMLFlag `xml:"reject"`
+	Resolve       configXMLFlag `xml:"resolve"`
+	NoResolve     configXMLFlag `xml:"no-resolve"`
+	Retain        configXMLFlag `xml:"retain"`
+	NoRetain      configXMLFlag `xml:"no-retain"`
+}
+
 func resourceStaticRoute() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceStaticRouteCreate,
@@ -48,7 +85,7 @@ func resourceStaticRoute() *schema.Resource {
 		UpdateContext: resourceStaticRouteUpdate,
 		DeleteContext: resourceStaticRouteDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceStaticRouteImport,
+			StateContext: resourceStaticRouteImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"destination": {
@@ -216,12 +253,14 @@ func resourceStaticRouteCreate(ctx context.Context, d *schema.ResourceData, m in
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if d.Get("routing_instance").(string) != defaultWord {
 		instanceExists, err := checkRoutingInstanceExists(d.Get("routing_instance").(string), m, jnprSess)
@@ -279,7 +318,7 @@ func resourceStaticRouteCreate(ctx context.Context, d *schema.ResourceData, m in
 
 func resourceStaticRouteRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -319,12 +358,14 @@ func resourceStaticRouteUpdate(ctx context.Context, d *schema.ResourceData, m in
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delStaticRoute(d.Get("destination").(string), d.Get("routing_instance").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -358,12 +399,14 @@ func resourceStaticRouteDelete(ctx context.Context, d *schema.ResourceData, m in
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delStaticRoute(d.Get("destination").(string), d.Get("routing_instance").(string), m, jnprSess); err != nil {
 		appendDiagWarns(&diagWarns, sess.configClear(jnprSess))
@@ -381,9 +424,10 @@ func resourceStaticRouteDelete(ctx context.Context, d *schema.ResourceData, m in
 	return diagWarns
 }
 
-func resourceStaticRouteImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+func resourceStaticRouteImport(
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -414,45 +458,24 @@ func resourceStaticRouteImport(d *schema.ResourceData, m interface{}) ([]*schema
 
 func checkStaticRouteExists(destination string, instance string, m interface{}, jnprSess *NetconfObject) (bool, error) {
 	sess := m.(*Session)
-	var showConfig string
-	var err error
-	if instance == defaultWord {
-		if !strings.Contains(destination, ":") {
-			showConfig, err = sess.command("show configuration"+
-				" routing-options static route "+destination+" | display set", jnprSess)
-			if err != nil {
-				return false, err
-			}
-		} else {
-			showConfig, err = sess.command("show configuration"+
-				" routing-options rib inet6.0 static route "+destination+" | display set", jnprSess)
-			if err != nil {
-				return false, err
-			}
-		}
-	} else {
-		if !strings.Contains(destination, ":") {
-			showConfig, err = sess.command("show configuration"+
-				" routing-instances "+instance+
-				" routing-options static route "+destination+" | display set", jnprSess)
-			if err != nil {
-				return false, err
-			}
-		} else {
-			showConfig, err = sess.command("show configuration"+
-				" routing-instances "+instance+
-				" routing-options rib "+instance+".inet6.0 static route "+destination+" | display set", jnprSess)
-			if err != nil {
-				return false, err
-			}
-		}
-	}
 
-	if showConfig == emptyWord {
-		return false, nil
+	return sess.configurationXML(staticRouteXMLPath(destination, instance), nil, jnprSess)
+}
+
+// staticRouteXMLPath returns the hierarchy of static route in configuration.
+func staticRouteXMLPath(destination string, instance string) []configXMLElement {
+	path := make([]configXMLElement, 0, 6)
+	rib := "inet6.0"
+	if instance != defaultWord {
+		path = append(path, xmlContainer("routing-instances"), xmlEntry("instance", instance))
+		rib = instance + ".inet6.0"
+	}
+	path = append(path, xmlContainer("routing-options"))
+	if strings.Contains(destination, ":") {
+		path = append(path, xmlEntry("rib", rib))
 	}
 
-	return true, nil
+	return append(path, xmlContainer("static"), xmlEntry("route", destination))
 }
 
 func setStaticRoute(d *schema.ResourceData, m interface{}, jnprSess *NetconfObject) error {
@@ -578,125 +601,151 @@ func readStaticRoute(destination string, instance string, m interface{},
 	jnprSess *NetconfObject) (staticRouteOptions, error) {
 	sess := m.(*Session)
 	var confRead staticRouteOptions
-	var showConfig string
-	var err error
+	var config staticRouteXML
 
-	if instance == defaultWord {
-		if !strings.Contains(destination, ":") {
-			showConfig, err = sess.command("show configuration"+
-				" routing-options static route "+destination+" | display set relative", jnprSess)
-		} else {
-			showConfig, err = sess.command("show configuration"+
-				" routing-options rib inet6.0 static route "+destination+" | display set relative", jnprSess)
+	if sess.offline != nil {
+		lines, err := sess.configurationSetLines(staticRouteXMLPath(destination, instance), jnprSess)
+		if err != nil {
+			return confRead, err
 		}
-	} else {
-		if !strings.Contains(destination, ":") {
-			showConfig, err = sess.command("show configuration"+
-				" routing-instances "+instance+
-				" routing-options static route "+destination+" | display set relative", jnprSess)
-		} else {
-			showConfig, err = sess.command("show configuration"+
-				" routing-instances "+instance+
-				" routing-options rib "+instance+".inet6.0 static route "+destination+" | display set relative", jnprSess)
+		if len(lines) == 0 {
+			return confRead, nil
 		}
+
+		return readStaticRouteLines(destination, instance, lines)
 	}
+	found, err := sess.configurationXML(staticRouteXMLPath(destination, instance), &config, jnprSess)
 	if err != nil {
 		return confRead, err
 	}
-
-	if showConfig != emptyWord {
+	if found {
 		confRead.destination = destination
 		confRead.routingInstance = instance
-		for _, item := range strings.Split(showConfig, "\n") {
-			if strings.Contains(item, "<configuration-output>") {
-				continue
+		confRead.active = bool(config.Active)
+		confRead.asPathAggregatorAsNumber = config.AsPath.Aggregator.AsNumber
+		confRead.asPathAggregatorAddress = config.AsPath.Aggregator.Address
+		confRead.asPathAtomicAggregate = bool(config.AsPath.AtomicAggregate)
+		confRead.asPathOrigin = config.AsPath.Origin
+		confRead.asPathPath = config.AsPath.Path
+		confRead.community = config.Community
+		confRead.discard = bool(config.Discard)
+		confRead.install = bool(config.Install)
+		confRead.noInstall = bool(config.NoInstall)
+		confRead.metric = int(config.Metric)
+		confRead.nextHop = config.NextHop
+		confRead.nextTable = config.NextTable
+		confRead.passive = bool(config.Passive)
+		confRead.preference = int(config.Preference)
+		for _, qualifiedNextHop := range config.QualifiedNextHop {
+			confRead.qualifiedNextHop = append(confRead.qualifiedNextHop, map[string]interface{}{
+				"next_hop":   qualifiedNextHop.Name,
+				"interface":  qualifiedNextHop.Interface,
+				"metric":     int(qualifiedNextHop.Metric),
+				"preference": int(qualifiedNextHop.Preference),
+			})
+		}
+		confRead.readvertise = bool(config.Readvertise)
+		confRead.noReadvertise = bool(config.NoReadvertise)
+		confRead.receive = bool(config.Receive)
+		confRead.reject = bool(config.Reject)
+		confRead.resolve = bool(config.Resolve)
+		confRead.noResolve = bool(config.NoResolve)
+		confRead.retain = bool(config.Retain)
+		confRead.noRetain = bool(config.NoRetain)
+	}
+
+	return confRead, nil
+}
+
+// readStaticRouteLines reads a static route with its relative `set` lines (offline mode).
+func readStaticRouteLines(destination string, instance string, lines []string) (staticRouteOptions, error) {
+	var confRead staticRouteOptions
+	var err error
+	confRead.destination = destination
+	confRead.routingInstance = instance
+	for _, itemTrim := range lines {
+		switch {
+		case itemTrim == "active":
+			confRead.active = true
+		case strings.HasPrefix(itemTrim, "as-path aggregator "):
+			itemTrimSplit := strings.Split(itemTrim, " ")
+			if len(itemTrimSplit) < 4 {
+				return confRead, fmt.Errorf("can't find as-number and address in '%s'", itemTrim)
 			}
-			if strings.Contains(item, "</configuration-output>") {
-				break
+			confRead.asPathAggregatorAsNumber = itemTrimSplit[2]
+			confRead.asPathAggregatorAddress = itemTrimSplit[3]
+		case itemTrim == asPathAtomicAggregate:
+			confRead.asPathAtomicAggregate = true
+		case strings.HasPrefix(itemTrim, "as-path origin "):
+			confRead.asPathOrigin = strings.TrimPrefix(itemTrim, "as-path origin ")
+		case strings.HasPrefix(itemTrim, "as-path path "):
+			confRead.asPathPath = strings.Trim(strings.TrimPrefix(itemTrim, "as-path path "), "\"")
+		case strings.HasPrefix(itemTrim, "community "):
+			confRead.community = append(confRead.community, strings.TrimPrefix(itemTrim, "community "))
+		case itemTrim == discardW:
+			confRead.discard = true
+		case itemTrim == "install":
+			confRead.install = true
+		case itemTrim == "no-install":
+			confRead.noInstall = true
+		case strings.HasPrefix(itemTrim, "metric "):
+			confRead.metric, err = strconv.Atoi(strings.TrimPrefix(itemTrim, "metric "))
+			if err != nil {
+				return confRead, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrim, err)
+			}
+		case strings.HasPrefix(itemTrim, "next-hop "):
+			confRead.nextHop = append(confRead.nextHop, strings.TrimPrefix(itemTrim, "next-hop "))
+		case strings.HasPrefix(itemTrim, "next-table "):
+			confRead.nextTable = strings.TrimPrefix(itemTrim, "next-table ")
+		case itemTrim == passiveW:
+			confRead.passive = true
+		case strings.HasPrefix(itemTrim, "preference "):
+			confRead.preference, err = strconv.Atoi(strings.TrimPrefix(itemTrim, "preference "))
+			if err != nil {
+				return confRead, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrim, err)
 			}
-			itemTrim := strings.TrimPrefix(item, setLineStart)
+		case strings.HasPrefix(itemTrim, "qualified-next-hop "):
+			nextHop := strings.TrimPrefix(itemTrim, "qualified-next-hop ")
+			nextHopWords := strings.Split(nextHop, " ")
+			qualifiedNextHopOptions := map[string]interface{}{
+				"next_hop":   nextHopWords[0],
+				"interface":  "",
+				"metric":     0,
+				"preference": 0,
+			}
+			confRead.qualifiedNextHop = copyAndRemoveItemMapList("next_hop", qualifiedNextHopOptions, confRead.qualifiedNextHop)
+			itemTrimQnh := strings.TrimPrefix(itemTrim, "qualified-next-hop "+nextHopWords[0]+" ")
 			switch {
-			case itemTrim == "active":
-				confRead.active = true
-			case strings.HasPrefix(itemTrim, "as-path aggregator "):
-				itemTrimSplit := strings.Split(itemTrim, " ")
-				confRead.asPathAggregatorAsNumber = itemTrimSplit[2]
-				confRead.asPathAggregatorAddress = itemTrimSplit[3]
-			case itemTrim == asPathAtomicAggregate:
-				confRead.asPathAtomicAggregate = true
-			case strings.HasPrefix(itemTrim, "as-path origin "):
-				confRead.asPathOrigin = strings.TrimPrefix(itemTrim, "as-path origin ")
-			case strings.HasPrefix(itemTrim, "as-path path "):
-				confRead.asPathPath = strings.Trim(strings.TrimPrefix(itemTrim, "as-path path "), "\"")
-			case strings.HasPrefix(itemTrim, "community "):
-				confRead.community = append(confRead.community, strings.TrimPrefix(itemTrim, "community "))
-			case itemTrim == discardW:
-				confRead.discard = true
-			case itemTrim == "install":
-				confRead.install = true
-			case itemTrim == "no-install":
-				confRead.noInstall = true
-			case strings.HasPrefix(itemTrim, "metric "):
-				confRead.metric, err = strconv.Atoi(strings.TrimPrefix(itemTrim, "metric "))
+			case strings.HasPrefix(itemTrimQnh, "interface "):
+				qualifiedNextHopOptions["interface"] = strings.TrimPrefix(itemTrimQnh, "interface ")
+			case strings.HasPrefix(itemTrimQnh, "metric "):
+				qualifiedNextHopOptions["metric"], err = strconv.Atoi(strings.TrimPrefix(itemTrimQnh, "metric "))
 				if err != nil {
-					return confRead, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrim, err)
+					return confRead, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrimQnh, err)
 				}
-			case strings.HasPrefix(itemTrim, "next-hop "):
-				confRead.nextHop = append(confRead.nextHop, strings.TrimPrefix(itemTrim, "next-hop "))
-			case strings.HasPrefix(itemTrim, "next-table "):
-				confRead.nextTable = strings.TrimPrefix(itemTrim, "next-table ")
-			case itemTrim == passiveW:
-				confRead.passive = true
-			case strings.HasPrefix(itemTrim, "preference "):
-				confRead.preference, err = strconv.Atoi(strings.TrimPrefix(itemTrim, "preference "))
+			case strings.HasPrefix(itemTrimQnh, "preference "):
+				qualifiedNextHopOptions["preference"], err = strconv.Atoi(strings.TrimPrefix(itemTrimQnh, "preference "))
 				if err != nil {
-					return confRead, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrim, err)
-				}
-			case strings.HasPrefix(itemTrim, "qualified-next-hop "):
-				nextHop := strings.TrimPrefix(itemTrim, "qualified-next-hop ")
-				nextHopWords := strings.Split(nextHop, " ")
-				qualifiedNextHopOptions := map[string]interface{}{
-					"next_hop":   nextHopWords[0],
-					"interface":  "",
-					"metric":     0,
-					"preference": 0,
-				}
-				confRead.qualifiedNextHop = copyAndRemoveItemMapList("next_hop", qualifiedNextHopOptions, confRead.qualifiedNextHop)
-				itemTrimQnh := strings.TrimPrefix(itemTrim, "qualified-next-hop "+nextHopWords[0]+" ")
-				switch {
-				case strings.HasPrefix(itemTrimQnh, "interface "):
-					qualifiedNextHopOptions["interface"] = strings.TrimPrefix(itemTrimQnh, "interface ")
-				case strings.HasPrefix(itemTrimQnh, "metric "):
-					qualifiedNextHopOptions["metric"], err = strconv.Atoi(
-						strings.TrimPrefix(itemTrimQnh, "metric "))
-					if err != nil {
-						return confRead, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrimQnh, err)
-					}
-				case strings.HasPrefix(itemTrimQnh, "preference "):
-					qualifiedNextHopOptions["preference"], err = strconv.Atoi(
-						strings.TrimPrefix(itemTrimQnh, "preference "))
-					if err != nil {
-						return confRead, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrimQnh, err)
-					}
+					return confRead, fmt.Errorf("failed to convert value from '%s' to integer : %w", itemTrimQnh, err)
 				}
-				confRead.qualifiedNextHop = append(confRead.qualifiedNextHop, qualifiedNextHopOptions)
-			case itemTrim == "readvertise":
-				confRead.readvertise = true
-			case itemTrim == "no-readvertise":
-				confRead.noReadvertise = true
-			case itemTrim == "receive":
-				confRead.receive = true
-			case itemTrim == "reject":
-				confRead.reject = true
-			case itemTrim == "resolve":
-				confRead.resolve = true
-			case itemTrim == "no-resolve":
-				confRead.noResolve = true
-			case itemTrim == "retain":
-				confRead.retain = true
-			case itemTrim == "no-retain":
-				confRead.noRetain = true
 			}
+			confRead.qualifiedNextHop = append(confRead.qualifiedNextHop, qualifiedNextHopOptions)
+		case itemTrim == "readvertise":
+			confRead.readvertise = true
+		case itemTrim == "no-readvertise":
+			confRead.noReadvertise = true
+		case itemTrim == "receive":
+			confRead.receive = true
+		case itemTrim == "reject":
+			confRead.reject = true
+		case itemTrim == "resolve":
+			confRead.resolve = true
+		case itemTrim == "no-resolve":
+			confRead.noResolve = true
+		case itemTrim == "retain":
+			confRead.retain = true
+		case itemTrim == "no-retain":
+			confRead.noRetain = true
 		}
 	}
 
@@ -0,0 +1,75 @@
+package junos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestFakeDeviceResourceStaticRoute(t *testing.T) {
+	sess, device := newFakeDeviceSession(t, "vsrx")
+	ctx := context.Background()
+	res := resourceStaticRoute()
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"destination":                  "192.0.2.0/24",
+		"as_path_aggregator_as_number": "65000",
+		"as_path_aggregator_address":   "192.0.2.1",
+		"as_path_origin":               "igp",
+		"as_path_path":                 "65000 65001",
+		"community":                    []interface{}{"no-advertise", "65000:100"},
+		"metric":                       10,
+		"next_hop":                     []interface{}{"198.51.100.1"},
+		"qualified_next_hop": []interface{}{
+			map[string]interface{}{
+				"next_hop": "198.51.100.2",
+			},
+			map[string]interface{}{
+				"next_hop":   "198.51.100.3",
+				"interface":  "ge-0/0/0.0",
+				"preference": 10,
+			},
+		},
+		"no_readvertise": true,
+	})
+	if diags := res.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+
+	read := res.Data(nil)
+	read.SetId(d.Id())
+	if err := read.Set("destination", "192.0.2.0/24"); err != nil {
+		t.Fatal(err)
+	}
+	if err := read.Set("routing_instance", defaultWord); err != nil {
+		t.Fatal(err)
+	}
+	if diags := res.ReadContext(ctx, read, sess); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if read.Id() == "" {
+		t.Fatalf("static route not found with get-configuration in XML:\n%q", device.Committed())
+	}
+	for k, v := range map[string]interface{}{
+		"destination":                     "192.0.2.0/24",
+		"as_path_aggregator_as_number":    "65000",
+		"as_path_aggregator_address":      "192.0.2.1",
+		"as_path_origin":                  "igp",
+		"as_path_path":                    "65000 65001",
+		"community.#":                     2,
+		"community.1":                     "65000:100",
+		"metric":                          10,
+		"next_hop.0":                      "198.51.100.1",
+		"qualified_next_hop.#":            2,
+		"qualified_next_hop.0.next_hop":   "198.51.100.2",
+		"qualified_next_hop.1.next_hop":   "198.51.100.3",
+		"qualified_next_hop.1.interface":  "ge-0/0/0.0",
+		"qualified_next_hop.1.preference": 10,
+		"no_readvertise":                  true,
+		"readvertise":                     false,
+	} {
+		if got := read.Get(k); got != v {
+			t.Errorf("unexpected %s %v after read, want %v", k, got, v)
+		}
+	}
+}
@@ -49,7 +49,7 @@ func resourceSystemServicesDhcpLocalServerGroup() *schema.Resource {
 		UpdateContext: resourceSystemServicesDhcpLocalServerGroupUpdate,
 		DeleteContext: resourceSystemServicesDhcpLocalServerGroupDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceSystemServicesDhcpLocalServerGroupImport,
+			StateContext: resourceSystemServicesDhcpLocalServerGroupImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -743,12 +743,14 @@ func resourceSystemServicesDhcpLocalServerGroupCreate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if d.Get("routing_instance").(string) != defaultWord {
 		instanceExists, err := checkRoutingInstanceExists(d.Get("routing_instance").(string), m, jnprSess)
@@ -824,7 +826,7 @@ func resourceSystemServicesDhcpLocalServerGroupCreate(ctx context.Context,
 func resourceSystemServicesDhcpLocalServerGroupRead(ctx context.Context,
 	d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -867,12 +869,14 @@ func resourceSystemServicesDhcpLocalServerGroupUpdate(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delSystemServicesDhcpLocalServerGroup(
 		d.Get("name").(string), d.Get("routing_instance").(string), d.Get("version").(string), m, jnprSess); err != nil {
@@ -908,12 +912,14 @@ func resourceSystemServicesDhcpLocalServerGroupDelete(ctx context.Context,
 
 		return nil
 	}
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	defer sess.closeSession(jnprSess)
-	sess.configLock(jnprSess)
+	if err := sess.configLock(jnprSess); err != nil {
+		return diag.FromErr(err)
+	}
 	var diagWarns diag.Diagnostics
 	if err := delSystemServicesDhcpLocalServerGroup(
 		d.Get("name").(string), d.Get("routing_instance").(string), d.Get("version").(string), m, jnprSess); err != nil {
@@ -933,9 +939,9 @@ func resourceSystemServicesDhcpLocalServerGroupDelete(ctx context.Context,
 }
 
 func resourceSystemServicesDhcpLocalServerGroupImport(
-	d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	sess := m.(*Session)
-	jnprSess, err := sess.startNewSession()
+	jnprSess, err := sess.startNewSession(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -1,41 +1,247 @@
 package junos
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"os"
 	"path"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jeremmfr/go-netconf/netconf"
 )
 
-const directoryPermission = 0o755
+const (
+	directoryPermission = 0o755
+
+	configModeExclusive = "exclusive"
+	configModePrivate   = "private"
+
+	commitConfirmedReconnectAttempts = 3
+)
 
-// Session information to connect on Junos Device and more.
+var (
+	providerSessions      = make([]*Session, 0) // nolint: gochecknoglobals
+	providerSessionsMutex = &sync.Mutex{}       // nolint: gochecknoglobals
+)
+
+// Session information to connect on Junos Device and more,
+// with the hierarchy of configuration where the resource is configured.
 type Session struct {
+	*sessionShared
+	prefix configPrefix
+}
+
+// sessionShared information to connect on Junos Device and more,
+// shared by the sessions of resources with a different configuration prefix.
+type sessionShared struct {
 	junosFakeUpdateAlso    bool
 	junosFakeDeleteAlso    bool
+	junosSSHHostKeyTOFU    bool
+	junosPlanCommitCheck   bool
+	junosShowCompare       bool
+	junosCommitSynchronize bool
+	junosCommitForceSync   bool
 	junosPort              int
 	junosSleepLock         int
+	junosLockMaxWait       int
 	junosSleepShort        int
 	junosSleepSSHClosed    int
+	junosSessionPoolSize   int
+	junosCommitConfirmed   int
+	junosConfirmedCheck    int
+	junosRPCTimeout        int
+	junosRetryAttempts     int
+	junosRetryBackoff      int
 	junosFilePermission    int64
 	junosIP                string
 	junosUserName          string
 	junosPassword          string
 	junosSSHKeyPEM         string
 	junosSSHKeyFile        string
+	junosSSHCertPEM        string
+	junosSSHCertFile       string
 	junosKeyPass           string
+	junosTransport         string
 	junosGroupIntDel       string
+	junosConfigMode        string
 	junosLogFile           string
 	junosFakeCreateSetFile string
+	junosFakeSetFileFormat string
+	junosCompareFile       string
+	junosCommentTemplate   string
+	junosSSHKnownHostsFile string
 	junosSSHCiphers        []string
+	junosSSHHostKeyFinger  []string
+	junosSSHJumpHosts      []netconfJumpHost
+	junosCommitCommentVars map[string]string
+	junosTLSConfig         *tls.Config
+	stopCtx                context.Context
+	pool                   *sessionPool
+	deferred               *deferredCommit
+	cache                  *configCache
+	offline                *offlineConfig
+	fakeTree               *configTree
+	redundancy             *redundancyCheck
+	dialTransport          func() (netconfTransport, error)
+	render                 *[]string
+}
+
+// redundancyCheck checks once if the device has redundant routing engines (or is a node of chassis cluster)
+// to warn that the commits aren't synchronized.
+type redundancyCheck struct {
+	once sync.Once
+}
+
+// CloseSessions discards the deferred changes not committed and closes the netconf sessions kept open
+// by the providers.
+// It need to be called when the provider server stops.
+func CloseSessions() {
+	providerSessionsMutex.Lock()
+	defer providerSessionsMutex.Unlock()
+	for _, sess := range providerSessions {
+		sess.stop()
+	}
+	providerSessions = make([]*Session, 0)
+}
+
+// registerSession keeps Session to stop it with the provider.
+func registerSession(ctx context.Context, sess *Session) {
+	providerSessionsMutex.Lock()
+	providerSessions = append(providerSessions, sess)
+	providerSessionsMutex.Unlock()
+	if stopCtx, ok := schema.StopContext(ctx); ok {
+		sess.stopCtx = stopCtx
+		go func() {
+			<-stopCtx.Done()
+			sess.interrupt()
+		}()
+	}
+}
+
+// interrupt discards the deferred changes and closes sessions in pool when Terraform is interrupted.
+func (sess *Session) interrupt() {
+	if sess.deferred != nil {
+		sess.deferred.discard(sess)
+	}
+	if sess.pool != nil {
+		sess.pool.closeAll(sess.junosSleepSSHClosed)
+	}
+}
+
+// stop discards the deferred changes not committed by a junos_commit resource
+// and closes sessions in pool.
+// The changes are never committed implicitly: the Terraform run is over and
+// the errors of a commit can't be reported on resources.
+func (sess *Session) stop() {
+	if sess.deferred != nil {
+		if changes := sess.deferred.uncommitted(); len(changes) > 0 {
+			err := fmt.Errorf("deferred changes not committed and discarded (%s): "+
+				"a junos_commit resource that depends on resources is required with commit_deferred",
+				strings.Join(changes, ", "))
+			sess.logFile(fmt.Sprintf("[stop] %q", err))
+			log.Printf("[ERROR] %s", err.Error())
+		}
+		sess.deferred.discard(sess)
+	}
+	if sess.pool != nil {
+		sess.pool.closeAll(sess.junosSleepSSHClosed)
+	}
+}
+
+func (sess *Session) startNewSession(ctx context.Context) (*NetconfObject, error) {
+	ctx = sess.sessionContext(ctx)
+	var jnpr *NetconfObject
+	var err error
+	if sess.pool != nil {
+		jnpr, err = sess.pool.get(ctx, sess)
+		if err != nil {
+			return nil, err
+		}
+		jnpr.ctx = ctx
+	} else {
+		jnpr, err = sess.openNetconfObject(ctx)
+		if err != nil {
+			return jnpr, err
+		}
+	}
+	if err := sess.checkConfigPrefixExists(ctx, jnpr); err != nil {
+		sess.closeSession(jnpr)
+
+		return nil, err
+	}
+
+	return jnpr, nil
+}
+
+// sessionContext returns a context canceled with ctx or when Terraform is interrupted.
+func (sess *Session) sessionContext(ctx context.Context) context.Context {
+	if sess.stopCtx == nil {
+		return ctx
+	}
+	sessCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-sess.stopCtx.Done():
+			cancel()
+		case <-sessCtx.Done():
+		}
+	}()
+
+	return sessCtx
+}
+
+// openNetconfObject opens a new netconf session, the rpc on this session are canceled with ctx.
+func (sess *Session) openNetconfObject(ctx context.Context) (*NetconfObject, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("netconf session not opened : %w", err)
+	}
+	jnpr, err := sess.dialNetconfFacts(ctx)
+	for attempt := 1; attempt <= sess.junosRetryAttempts && isTransportError(err); attempt++ {
+		if jnpr != nil {
+			jnpr.closeTransport()
+		}
+		sess.logFile(fmt.Sprintf("[startNewSession] failed, retry %d/%d: %q", attempt, sess.junosRetryAttempts, err))
+		if errSleep := sleepContext(ctx, sess.retryBackoff(attempt)); errSleep != nil {
+			return nil, err
+		}
+		jnpr, err = sess.dialNetconfFacts(ctx)
+	}
+	if err != nil {
+		return jnpr, err
+	}
+	sess.logFile("[startNewSession] started")
+
+	return jnpr, nil
 }
 
-func (sess *Session) startNewSession() (*NetconfObject, error) {
+// dialNetconf connects to the device with transport of provider.
+func (sess *Session) dialNetconf() (*NetconfObject, error) {
+	if sess.dialTransport != nil {
+		transport, err := sess.dialTransport()
+		if err != nil {
+			return nil, err
+		}
+
+		return newSessionFromNetconf(transport)
+	}
+	if sess.junosTransport == transportTLS {
+		return netconfNewSessionTLS(net.JoinHostPort(sess.junosIP, strconv.Itoa(sess.junosPort)), sess.junosTLSConfig)
+	}
 	var auth netconfAuthMethod
 	auth.Username = sess.junosUserName
 	auth.Ciphers = sess.junosSSHCiphers
+	auth.KnownHostsFile = sess.junosSSHKnownHostsFile
+	auth.HostKeyFingerprints = sess.junosSSHHostKeyFinger
+	auth.HostKeyTOFU = sess.junosSSHHostKeyTOFU
+	auth.FilePermission = sess.junosFilePermission
 	if sess.junosSSHKeyPEM != "" {
 		auth.PrivateKeyPEM = sess.junosSSHKeyPEM
 		if sess.junosKeyPass != "" {
@@ -48,22 +254,40 @@ func (sess *Session) startNewSession() (*NetconfObject, error) {
 			auth.Passphrase = sess.junosKeyPass
 		}
 	}
-	if sess.junosPassword != "" {
-		auth.Password = sess.junosPassword
+	if sess.junosSSHCertPEM != "" {
+		auth.CertificatePEM = sess.junosSSHCertPEM
 	}
-	jnpr, err := netconfNewSession(sess.junosIP+":"+strconv.Itoa(sess.junosPort), &auth)
-	if err != nil {
-		return nil, err
+	if sess.junosSSHCertFile != "" {
+		auth.CertificateFile = sess.junosSSHCertFile
 	}
-	if jnpr.SystemInformation.HardwareModel == "" {
-		return jnpr, fmt.Errorf("can't read model of device with <get-system-information/> netconf command")
+	if sess.junosPassword != "" {
+		auth.Password = sess.junosPassword
 	}
-	sess.logFile("[startNewSession] started")
 
-	return jnpr, nil
+	return netconfNewSession(sess.junosIP+":"+strconv.Itoa(sess.junosPort), &auth, sess.junosSSHJumpHosts)
 }
 
 func (sess *Session) closeSession(jnpr *NetconfObject) {
+	if sess.pool != nil {
+		if jnpr.closed {
+			sess.logFile("[closeSession] discard closed session from pool")
+			sess.pool.discard(jnpr)
+
+			return
+		}
+		if jnpr.locked {
+			if errs := sess.configClear(jnpr); len(errs) > 0 {
+				sess.logFile(fmt.Sprintf("[closeSession] discard session from pool, err: %q", errs))
+				sess.pool.discard(jnpr)
+
+				return
+			}
+		}
+		sess.pool.put(jnpr)
+		sess.logFile("[closeSession] released in pool")
+
+		return
+	}
 	err := jnpr.close(sess.junosSleepSSHClosed)
 	if err != nil {
 		sess.logFile(fmt.Sprintf("[closeSession] err: %q", err))
@@ -73,7 +297,33 @@ func (sess *Session) closeSession(jnpr *NetconfObject) {
 }
 
 func (sess *Session) command(cmd string, jnpr *NetconfObject) (string, error) {
-	read, err := jnpr.netconfCommand(cmd)
+	cmd = sess.prefix.command(cmd)
+	if sess.deferred != nil && sess.deferred.hasChanges() {
+		read, ok, err := sess.deferred.command(jnpr.ctx, sess, cmd)
+		if ok {
+			if err != nil {
+				return "", err
+			}
+
+			return read, nil
+		}
+	}
+	if sess.cache != nil {
+		read, ok, err := sess.cache.command(sess, cmd, jnpr)
+		if ok {
+			if err != nil {
+				return "", err
+			}
+
+			return read, nil
+		}
+	}
+	var read string
+	err := sess.retry(jnpr, "command", func() (err error) {
+		read, err = jnpr.netconfCommand(cmd)
+
+		return err
+	})
 	sess.logFile(fmt.Sprintf("[command] cmd: %q", cmd))
 	sess.logFile(fmt.Sprintf("[command] read: %q", read))
 	sleepShort(sess.junosSleepShort)
@@ -87,7 +337,12 @@ func (sess *Session) command(cmd string, jnpr *NetconfObject) (string, error) {
 }
 
 func (sess *Session) commandXML(cmd string, jnpr *NetconfObject) (string, error) {
-	read, err := jnpr.netconfCommandXML(cmd)
+	var read string
+	err := sess.retry(jnpr, "commandXML", func() (err error) {
+		read, err = jnpr.netconfCommandXML(cmd)
+
+		return err
+	})
 	sess.logFile(fmt.Sprintf("[commandXML] cmd: %q", cmd))
 	sess.logFile(fmt.Sprintf("[commandXML] read: %q", read))
 	sleepShort(sess.junosSleepShort)
@@ -101,8 +356,17 @@ func (sess *Session) commandXML(cmd string, jnpr *NetconfObject) (string, error)
 }
 
 func (sess *Session) configSet(cmd []string, jnpr *NetconfObject) error {
+	cmd = sess.prefix.lines(cmd)
 	if jnpr != nil {
-		message, err := jnpr.netconfConfigSet(cmd)
+		if jnpr.deferred {
+			return sess.deferred.configSet(sess, cmd)
+		}
+		var message string
+		err := sess.retry(jnpr, "configSet", func() (err error) {
+			message, err = jnpr.netconfConfigSet(cmd)
+
+			return err
+		})
 		sleepShort(sess.junosSleepShort)
 		sess.logFile(fmt.Sprintf("[configSet] cmd: %q", cmd))
 		sess.logFile(fmt.Sprintf("[configSet] message: %q", message))
@@ -111,6 +375,11 @@ func (sess *Session) configSet(cmd []string, jnpr *NetconfObject) error {
 
 			return err
 		}
+		jnpr.pending = append(jnpr.pending, cmd...)
+
+		return nil
+	} else if sess.render != nil {
+		*sess.render = append(*sess.render, cmd...)
 
 		return nil
 	} else if sess.junosFakeCreateSetFile != "" {
@@ -127,6 +396,9 @@ func (sess *Session) appendFakeCreateSetFile(lines []string) error {
 			return fmt.Errorf("failed to create parent directory of `%s` : %w", sess.junosFakeCreateSetFile, err)
 		}
 	}
+	if sess.fakeTree != nil {
+		return sess.writeFakeConfigFile(lines)
+	}
 	f, err := os.OpenFile(sess.junosFakeCreateSetFile,
 		os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(sess.junosFilePermission))
 	if err != nil {
@@ -142,10 +414,42 @@ func (sess *Session) appendFakeCreateSetFile(lines []string) error {
 	return nil
 }
 
+// writeFakeConfigFile adds lines to the configuration tree of the run and rewrites the file
+// with the configuration tree in the format of fake file.
+func (sess *Session) writeFakeConfigFile(lines []string) error {
+	sess.fakeTree.mutex.Lock()
+	defer sess.fakeTree.mutex.Unlock()
+	for _, line := range lines {
+		sess.fakeTree.apply(line)
+	}
+	var config string
+	switch sess.junosFakeSetFileFormat {
+	case setFileFormatText:
+		config = sess.fakeTree.text()
+	case setFileFormatXML:
+		config = sess.fakeTree.xml()
+	}
+	tmpFile := sess.junosFakeCreateSetFile + ".tmp"
+	if err := os.WriteFile(tmpFile, []byte(config), os.FileMode(sess.junosFilePermission)); err != nil {
+		return fmt.Errorf("failed to write in file `%s` : %w", tmpFile, err)
+	}
+	if err := os.Rename(tmpFile, sess.junosFakeCreateSetFile); err != nil {
+		return fmt.Errorf("failed to replace file `%s` : %w", sess.junosFakeCreateSetFile, err)
+	}
+
+	return nil
+}
+
 func (sess *Session) commitConf(logMessage string, jnpr *NetconfObject) (_warnings []error, _err error) {
+	if jnpr.deferred {
+		return sess.deferred.check(sess, logMessage)
+	}
+	logMessage = sess.commitComment(jnpr.ctx, logMessage)
 	sess.logFile(fmt.Sprintf("[commitConf] commit %q", logMessage))
-	warns, err := jnpr.netconfCommit(logMessage)
-	sleepShort(sess.junosSleepShort)
+	warns := sess.showCompare(logMessage, jnpr)
+	warns = append(warns, sess.synchronizeWarning(jnpr)...)
+	warnsCommit, err := sess.commitWithConfirm(logMessage, jnpr)
+	warns = append(warns, warnsCommit...)
 	if len(warns) > 0 {
 		for _, w := range warns {
 			sess.logFile(fmt.Sprintf("[commitConf] commit warning: %q", w))
@@ -160,34 +464,372 @@ func (sess *Session) commitConf(logMessage string, jnpr *NetconfObject) (_warnin
 	return warns, nil
 }
 
-func (sess *Session) configLock(jnpr *NetconfObject) {
-	var lock bool
+// showCompare reads the differences of candidate configuration to commit (`show | compare`),
+// returns them as warning and writes them in compare file if enabled.
+// Errors to read the differences are only logged to not block the commit.
+func (sess *Session) showCompare(logMessage string, jnpr *NetconfObject) []error {
+	if !sess.junosShowCompare && sess.junosCompareFile == "" {
+		return []error{}
+	}
+	var compare string
+	err := sess.retry(jnpr, "showCompare", func() (err error) {
+		compare, err = jnpr.netconfCompareRollback()
+
+		return err
+	})
+	sleepShort(sess.junosSleepShort)
+	if err != nil {
+		sess.logFile(fmt.Sprintf("[showCompare] err: %q", err))
+
+		return []error{}
+	}
+	sess.logFile(fmt.Sprintf("[showCompare] %q: %q", logMessage, compare))
+	if compare == "" {
+		return []error{}
+	}
+	if sess.junosCompareFile != "" {
+		if err := sess.appendCompareFile(logMessage, compare); err != nil {
+			sess.logFile(fmt.Sprintf("[showCompare] err: %q", err))
+		}
+	}
+	if !sess.junosShowCompare {
+		return []error{}
+	}
+
+	return []error{fmt.Errorf("show | compare before commit %q on %s:\n%s",
+		logMessage, jnpr.SystemInformation.HostName, compare)}
+}
+
+func (sess *Session) appendCompareFile(logMessage, compare string) error {
+	f, err := os.OpenFile(sess.junosCompareFile,
+		os.O_APPEND|os.O_CREATE|os.O_WRONLY, os.FileMode(sess.junosFilePermission))
+	if err != nil {
+		return fmt.Errorf("failed to openfile `%s` : %w", sess.junosCompareFile, err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(fmt.Sprintf("# %s commit %q on %s\n%s\n\n",
+		time.Now().Format("2006-01-02 15:04:05"), logMessage, sess.junosIP, compare)); err != nil {
+		return fmt.Errorf("failed to write in file `%s` : %w", sess.junosCompareFile, err)
+	}
+
+	return nil
+}
+
+// commitSynchronize returns the elements of commit rpc to synchronize the commit
+// on the other routing engine (or node of chassis cluster) if enabled.
+func (sess *Session) commitSynchronize() string {
+	switch {
+	case sess.junosCommitForceSync:
+		return rpcForceSynchronize
+	case sess.junosCommitSynchronize:
+		return rpcSynchronize
+	default:
+		return ""
+	}
+}
+
+// synchronizeWarning returns (only for the first commit) a warning if the device is a node of chassis cluster
+// or has multiple routing engines and the commits aren't synchronized
+// (by provider or with `system commit synchronize` on device).
+// Errors to read the routing engines are only logged.
+func (sess *Session) synchronizeWarning(jnpr *NetconfObject) []error {
+	if sess.redundancy == nil || sess.commitSynchronize() != "" {
+		return []error{}
+	}
+	warns := make([]error, 0)
+	sess.redundancy.once.Do(func() {
+		// read directly on session: jnpr can be the dedicated session of deferred commit
+		// (reading through sess.command would wait on the mutex of deferred commit)
+		// and the commit settings are outside of the configuration prefix
+		var showConfig string
+		err := sess.retry(jnpr, "synchronizeWarning", func() (err error) {
+			showConfig, err = jnpr.netconfCommand("show configuration system commit | display set relative")
+
+			return err
+		})
+		sleepShort(sess.junosSleepShort)
+		if err != nil && showConfig != emptyWord {
+			sess.logFile(fmt.Sprintf("[synchronizeWarning] err: %q", err))
+
+			return
+		}
+		if strings.Contains(showConfig, "set synchronize") {
+			return
+		}
+		if clusterNode := jnpr.SystemInformation.ClusterNode; clusterNode != nil && *clusterNode {
+			warns = append(warns, fmt.Errorf("device %s is a node of chassis cluster but 'commit_synchronize' "+
+				"isn't set, the configuration isn't synchronized on the other node", jnpr.SystemInformation.HostName))
+
+			return
+		}
+		var routeEngines int
+		err = sess.retry(jnpr, "synchronizeWarning", func() (err error) {
+			routeEngines, err = jnpr.netconfRouteEngines()
+
+			return err
+		})
+		sleepShort(sess.junosSleepShort)
+		if err != nil {
+			sess.logFile(fmt.Sprintf("[synchronizeWarning] err: %q", err))
+
+			return
+		}
+		if routeEngines > 1 {
+			warns = append(warns, fmt.Errorf("device %s has %d routing engines but 'commit_synchronize' "+
+				"isn't set, the configuration isn't synchronized on the backup routing engine",
+				jnpr.SystemInformation.HostName, routeEngines))
+		}
+	})
+
+	return warns
+}
+
+// commitWithConfirm commits the configuration and if commit confirmed is enabled,
+// open a new session to check reachability of device before confirm the commit.
+func (sess *Session) commitWithConfirm(logMessage string, jnpr *NetconfObject) (_warnings []error, _err error) {
+	if sess.cache != nil {
+		defer sess.cache.invalidate(sess)
+	}
+	if sess.offline != nil {
+		defer func() {
+			if _err == nil {
+				_err = sess.offline.save()
+			}
+		}()
+	}
+	if sess.junosCommitConfirmed == 0 {
+		warns, err := sess.commitResume(jnpr, func() ([]error, error) {
+			return jnpr.netconfCommit(logMessage, sess.commitSynchronize())
+		})
+		sleepShort(sess.junosSleepShort)
+		if err == nil {
+			jnpr.pending = nil
+		}
+
+		return warns, err
+	}
+	warns, err := sess.commitResume(jnpr, func() ([]error, error) {
+		return jnpr.netconfCommitConfirmed(logMessage, sess.commitSynchronize(), sess.junosCommitConfirmed)
+	})
+	sleepShort(sess.junosSleepShort)
+	if err != nil {
+		return warns, err
+	}
+	sess.logFile(fmt.Sprintf("[commitConf] commit confirmed %d, check reachability with a new session",
+		sess.junosCommitConfirmed))
+	if err := sess.checkReachability(jnpr.ctx); err != nil {
+		return warns, fmt.Errorf("device unreachable with a new session after commit confirmed, "+
+			"the configuration will be rolled back automatically by device in %d minute(s) : %w",
+			sess.junosCommitConfirmed, err)
+	}
+	sess.logFile("[commitConf] confirm commit")
+	// without differences in candidate configuration, the confirming commit can be replayed
+	// on a new session if the session is lost
+	var warnsConfirm []error
+	err = sess.retry(jnpr, "confirm commit", func() (err error) {
+		warnsConfirm, err = jnpr.netconfCommit(logMessage, sess.commitSynchronize())
+
+		return err
+	})
+	sleepShort(sess.junosSleepShort)
+	warns = append(warns, warnsConfirm...)
+	if err != nil {
+		return warns, fmt.Errorf("failed to confirm commit, the configuration will be rolled back automatically "+
+			"by device in %d minute(s) : %w", sess.junosCommitConfirmed, err)
+	}
+	jnpr.pending = nil
+
+	return warns, nil
+}
+
+// commitResume commits and, if the session is lost during commit, resumes the changes on
+// a new session (lock and load the pending lines) before commit again.
+// The device may have applied the commit before the session was lost, so the candidate configuration
+// with the pending lines is compared with the committed configuration on the new session and
+// the commit is replayed only if there are differences.
+func (sess *Session) commitResume(
+	jnpr *NetconfObject, commit func() ([]error, error)) (_warnings []error, _err error) {
+	var warns []error
+	resumed := false
+	err := sess.retry(jnpr, "commit", func() (err error) {
+		if resumed {
+			compare, err := jnpr.netconfCompareRollback()
+			sleepShort(sess.junosSleepShort)
+			if err != nil {
+				return fmt.Errorf("failed to check if the commit interrupted by the loss of session "+
+					"has been applied : %w", err)
+			}
+			if compare == "" {
+				sess.logFile("[commitResume] changes already committed before the loss of session, " +
+					"commit not replayed")
+
+				return nil
+			}
+			sess.logFile(fmt.Sprintf("[commitResume] changes not committed before the loss of session: %q", compare))
+		}
+		resumed = true
+		warns, err = commit()
+
+		return err
+	})
+
+	return warns, err
+}
+
+// checkReachability opens (and closes) a new session on device.
+func (sess *Session) checkReachability(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var err error
+	for i := 0; i < commitConfirmedReconnectAttempts; i++ {
+		if i > 0 {
+			if errCtx := sleepContext(ctx, time.Duration(sess.junosConfirmedCheck)*time.Second); errCtx != nil {
+				return errCtx
+			}
+		}
+		var jnpr *NetconfObject
+		jnpr, err = sess.openNetconfObject(ctx)
+		if err == nil {
+			if errClose := jnpr.close(sess.junosSleepSSHClosed); errClose != nil {
+				sess.logFile(fmt.Sprintf("[checkReachability] close err: %q", errClose))
+			}
+
+			return nil
+		}
+		if jnpr != nil {
+			jnpr.closeTransport()
+		}
+		sess.logFile(fmt.Sprintf("[checkReachability] attempt %d err: %q", i+1, err))
+	}
+
+	return err
+}
+
+// commitDeferred commits the changes of resources deferred in candidate configuration
+// and returns the committed changes.
+func (sess *Session) commitDeferred(
+	ctx context.Context, logMessage string) (_changes []string, _warnings []error, _err error) {
+	if sess.deferred == nil {
+		return []string{}, []error{}, nil
+	}
+
+	return sess.deferred.commit(sess.sessionContext(ctx), sess, logMessage)
+}
+
+func (sess *Session) configLock(jnpr *NetconfObject) error {
+	if sess.deferred != nil && !sess.deferred.isDestroyed() {
+		if err := sess.deferred.lock(jnpr.ctx, sess); err != nil {
+			return err
+		}
+		jnpr.deferred = true
+
+		return nil
+	}
+
+	return sess.retry(jnpr, "configLock", func() error {
+		return sess.waitConfigLock(jnpr)
+	})
+}
+
+// waitConfigLock locks candidate (or opens a private candidate) and waits until the lock
+// is acquired or the maximum wait is reached.
+func (sess *Session) waitConfigLock(jnpr *NetconfObject) error {
+	lockFunc := jnpr.netconfConfigLock
+	if sess.junosConfigMode == configModePrivate {
+		lockFunc = jnpr.netconfConfigOpenPrivate
+	}
+	var deadline time.Time
+	wait := time.Duration(sess.junosSleepLock) * time.Second
+	if sess.junosLockMaxWait > 0 {
+		deadline = time.Now().Add(time.Duration(sess.junosLockMaxWait) * time.Second)
+		if wait < time.Second {
+			wait = time.Second
+		}
+	}
 	for {
-		lock = jnpr.netconfConfigLock()
-		if lock {
+		err := lockFunc()
+		if err == nil {
+			jnpr.locked = true
 			sess.logFile("[configLock] locked")
 			sleepShort(sess.junosSleepShort)
 
-			break
-		} else {
-			sess.logFile("[configLock] sleep for wait lock")
-			sleep(sess.junosSleepLock)
+			return nil
+		}
+		var rpcErr *netconf.RPCError
+		if !errors.As(err, &rpcErr) {
+			sess.logFile(fmt.Sprintf("[configLock] err: %q", err))
+
+			return fmt.Errorf("failed to lock candidate configuration : %w", err)
+		}
+		sess.logFile(fmt.Sprintf("[configLock] lock failed: %q", err))
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				return fmt.Errorf("candidate configuration still locked after waiting %d seconds, "+
+					"last message from device: %s", sess.junosLockMaxWait, strings.TrimSpace(rpcErr.Message))
+			}
+			if wait > remaining {
+				wait = remaining
+			}
+			sess.logFile(fmt.Sprintf("[configLock] sleep %s for wait lock", wait))
+			if err := sleepContext(jnpr.ctx, wait); err != nil {
+				return fmt.Errorf("failed to lock candidate configuration : %w", err)
+			}
+			wait *= 2
+
+			continue
+		}
+		sess.logFile("[configLock] sleep for wait lock")
+		if err := sleepContext(jnpr.ctx, wait); err != nil {
+			return fmt.Errorf("failed to lock candidate configuration : %w", err)
 		}
 	}
 }
 
 func (sess *Session) configClear(jnpr *NetconfObject) (errs []error) {
+	if jnpr.deferred {
+		jnpr.deferred = false
+
+		return sess.deferred.clear(sess)
+	}
+	jnpr.pending = nil
+	if sess.junosConfigMode == configModePrivate {
+		errs = append(errs, jnpr.netconfConfigCloseConfig()...)
+		jnpr.locked = false
+		sleepShort(sess.junosSleepShort)
+		sess.logFile("[configClear] close private configuration")
+
+		return
+	}
 	errs = append(errs, jnpr.netconfConfigClear()...)
 	sleepShort(sess.junosSleepShort)
 	sess.logFile("[configClear] config clear")
 
 	errs = append(errs, jnpr.netconfConfigUnlock()...)
+	jnpr.locked = false
 	sleepShort(sess.junosSleepShort)
 	sess.logFile("[configClear] config unlock")
 
 	return
 }
 
+// closeDedicatedSession unlocks candidate and closes the session dedicated to deferred commit.
+func (sess *Session) closeDedicatedSession(jnpr *NetconfObject) {
+	release := jnpr.netconfConfigUnlock
+	if sess.junosConfigMode == configModePrivate {
+		release = jnpr.netconfConfigCloseConfig
+	}
+	for _, err := range release() {
+		sess.logFile(fmt.Sprintf("[closeDedicatedSession] err: %q", err))
+	}
+	if err := jnpr.close(sess.junosSleepSSHClosed); err != nil {
+		sess.logFile(fmt.Sprintf("[closeDedicatedSession] err: %q", err))
+	} else {
+		sess.logFile("[closeDedicatedSession] closed")
+	}
+}
+
 // log message in junosLogFile.
 func (sess *Session) logFile(message string) {
 	if sess.junosLogFile != "" {
@@ -209,6 +851,23 @@ func sleep(timeSleep int) {
 	time.Sleep(time.Duration(timeSleep) * time.Second)
 }
 
+// sleepContext waits the duration or returns the error of ctx if it's canceled before.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if ctx == nil {
+		time.Sleep(d)
+
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 func sleepShort(timeSleep int) {
 	time.Sleep(time.Duration(timeSleep) * time.Millisecond)
 }
@@ -0,0 +1,89 @@
+package junos
+
+import (
+	"fmt"
+	"sync"
+)
+
+// configCache is a snapshot of the committed configuration read once and used to serve
+// the reads of resources until the next commit.
+type configCache struct {
+	loadedSet bool
+	loadedXML bool
+	mutex     sync.Mutex
+	xml       string
+	lines     []string
+}
+
+// command serves `show configuration` commands with the set lines of snapshot.
+// Return false if the command can't be served with the snapshot.
+func (c *configCache) command(sess *Session, cmd string, jnpr *NetconfObject) (string, bool, error) {
+	filter, ok := newSetLinesFilter(cmd)
+	if !ok {
+		return "", false, nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.loadedSet {
+		var lines []string
+		err := sess.retry(jnpr, "config cache", func() (err error) {
+			lines, err = jnpr.netconfCommittedSetLines()
+
+			return err
+		})
+		sleepShort(sess.junosSleepShort)
+		if err != nil {
+			sess.logFile(fmt.Sprintf("[command] config cache err: %q", err))
+
+			return "", true, err
+		}
+		sess.logFile(fmt.Sprintf("[command] config cache loaded with %d lines", len(lines)))
+		c.lines = lines
+		c.loadedSet = true
+	}
+	read := filter.output(c.lines)
+	sess.logFile(fmt.Sprintf("[command] config cache cmd: %q", cmd))
+	sess.logFile(fmt.Sprintf("[command] config cache read: %q", read))
+
+	return read, true, nil
+}
+
+// configurationXML decodes a hierarchy of the snapshot in XML.
+func (c *configCache) configurationXML(
+	sess *Session, path []configXMLElement, v interface{}, jnpr *NetconfObject) (bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.loadedXML {
+		var config string
+		err := sess.retry(jnpr, "config cache", func() (err error) {
+			config, err = jnpr.netconfCommandXML(rpcGetCommittedConfigXML)
+
+			return err
+		})
+		sleepShort(sess.junosSleepShort)
+		if err != nil {
+			sess.logFile(fmt.Sprintf("[configurationXML] config cache err: %q", err))
+
+			return false, err
+		}
+		sess.logFile(fmt.Sprintf("[configurationXML] config cache loaded with %d bytes", len(config)))
+		c.xml = config
+		c.loadedXML = true
+	}
+	sess.logFile(fmt.Sprintf("[configurationXML] config cache read: %q", configXMLFilter(path)))
+
+	return decodeConfigXML(c.xml, path, v)
+}
+
+// invalidate drops the snapshot (after a commit), the next read loads a new snapshot.
+func (c *configCache) invalidate(sess *Session) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.loadedSet || c.loadedXML {
+		sess.logFile("[commitConf] config cache invalidated")
+	}
+	c.loadedSet = false
+	c.loadedXML = false
+	c.xml = ""
+	c.lines = nil
+}
@@ -0,0 +1,62 @@
+package junos
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestConfigCacheCommand(t *testing.T) {
+	sess, stub := newTLSStubSession(t)
+	sess.cache = &configCache{}
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer jnpr.closeTransport()
+	for i := 0; i < 2; i++ {
+		read, err := sess.command("show configuration routing-options static route 192.0.2.0/24 | display set", jnpr)
+		if err != nil {
+			t.Fatalf("command: %s", err)
+		}
+		if !strings.Contains(read, "set routing-options static route 192.0.2.0/24 discard") {
+			t.Errorf("unexpected output of command %q", read)
+		}
+	}
+	if count := countRequests(stub, rpcGetCommittedConfigSet); count != 1 {
+		t.Errorf("configuration read %d times, expected 1", count)
+	}
+	if count := countRequests(stub, "<command"); count != 0 {
+		t.Errorf("%d commands sent to device, expected 0", count)
+	}
+	sess.cache.invalidate(sess)
+	if _, err := sess.command("show configuration system host-name | display set", jnpr); err != nil {
+		t.Fatalf("command: %s", err)
+	}
+	if count := countRequests(stub, rpcGetCommittedConfigSet); count != 2 {
+		t.Errorf("configuration read %d times after invalidate, expected 2", count)
+	}
+}
+
+func TestConfigCacheConfigurationXML(t *testing.T) {
+	sess, stub := newTLSStubSession(t)
+	sess.cache = &configCache{}
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer jnpr.closeTransport()
+	for _, destination := range []string{"192.0.2.0/24", "198.51.100.0/24"} {
+		var route staticRouteXML
+		found, err := sess.configurationXML(staticRouteXMLPath(destination, defaultWord), &route, jnpr)
+		if err != nil {
+			t.Fatalf("configurationXML: %s", err)
+		}
+		if found != (destination == "192.0.2.0/24") {
+			t.Errorf("unexpected found %t for route %s", found, destination)
+		}
+	}
+	if count := countRequests(stub, rpcGetCommittedConfigXML); count != 1 {
+		t.Errorf("configuration read %d times, expected 1", count)
+	}
+}
@@ -0,0 +1,259 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	commitCommentMessage      = "message"
+	commitCommentOperation    = "operation"
+	commitCommentResourceType = "resource_type"
+	commitCommentResourceID   = "resource_id"
+	commitCommentHost         = "host"
+	commitCommentEnvPrefix    = "env."
+	commitCommentVarPrefix    = "var."
+)
+
+// commitCommentPlaceholder matches the placeholders `{{ name }}` in commit comment template.
+var commitCommentPlaceholder = regexp.MustCompile(`{{\s*([^{}\s]+)\s*}}`) // nolint: gochecknoglobals
+
+// commitResourceIDs are the IDs of resources, computed from their data like their create function
+// after the commit, for the resources with an ID different from their `name` argument.
+var commitResourceIDs = map[string]func(d *schema.ResourceData) string{ // nolint: gochecknoglobals
+	"junos_access_address_assignment_pool": func(d *schema.ResourceData) string {
+		return d.Get("name").(string) + idSeparator + d.Get("routing_instance").(string)
+	},
+	"junos_aggregate_route": func(d *schema.ResourceData) string {
+		return d.Get("destination").(string) + idSeparator + d.Get("routing_instance").(string)
+	},
+	"junos_bgp_group": func(d *schema.ResourceData) string {
+		return d.Get("name").(string) + idSeparator + d.Get("routing_instance").(string)
+	},
+	"junos_bgp_neighbor": func(d *schema.ResourceData) string {
+		return d.Get("ip").(string) + idSeparator + d.Get("routing_instance").(string) +
+			idSeparator + d.Get("group").(string)
+	},
+	"junos_bridge_domain": func(d *schema.ResourceData) string {
+		return d.Get("name").(string) + idSeparator + d.Get("routing_instance").(string)
+	},
+	"junos_chassis_cluster": func(*schema.ResourceData) string {
+		return "cluster"
+	},
+	"junos_commit": func(*schema.ResourceData) string {
+		return "commit"
+	},
+	"junos_config_lines": func(d *schema.ResourceData) string {
+		return configLinesID(d.Get("hierarchy").(string))
+	},
+	"junos_evpn": func(d *schema.ResourceData) string {
+		return d.Get("routing_instance").(string)
+	},
+	"junos_firewall_filter": func(d *schema.ResourceData) string {
+		return d.Get("name").(string) + idSeparator + d.Get("family").(string)
+	},
+	"junos_generate_route": func(d *schema.ResourceData) string {
+		return d.Get("destination").(string) + idSeparator + d.Get("routing_instance").(string)
+	},
+	"junos_null_commit_file": func(d *schema.ResourceData) string {
+		return d.Get("filename").(string)
+	},
+	"junos_ospf": func(d *schema.ResourceData) string {
+		return d.Get("version").(string) + idSeparator + d.Get("routing_instance").(string)
+	},
+	"junos_ospf_area": func(d *schema.ResourceData) string {
+		return d.Get("area_id").(string) + idSeparator + d.Get("version").(string) +
+			idSeparator + d.Get("routing_instance").(string)
+	},
+	"junos_routing_options": func(*schema.ResourceData) string {
+		return "routing_options"
+	},
+	"junos_security": func(*schema.ResourceData) string {
+		return "security"
+	},
+	"junos_security_global_policy": func(*schema.ResourceData) string {
+		return "security_global_policy"
+	},
+	"junos_security_nat_static_rule": func(d *schema.ResourceData) string {
+		return d.Get("rule_set").(string) + idSeparator + d.Get("name").(string)
+	},
+	"junos_security_policy": func(d *schema.ResourceData) string {
+		return d.Get("from_zone").(string) + idSeparator + d.Get("to_zone").(string)
+	},
+	"junos_security_policy_tunnel_pair_policy": func(d *schema.ResourceData) string {
+		return d.Get("zone_a").(string) + idSeparator + d.Get("policy_a_to_b").(string) +
+			idSeparator + d.Get("zone_b").(string) + idSeparator + d.Get("policy_b_to_a").(string)
+	},
+	"junos_security_zone_book_address": func(d *schema.ResourceData) string {
+		return d.Get("zone").(string) + idSeparator + d.Get("name").(string)
+	},
+	"junos_security_zone_book_address_set": func(d *schema.ResourceData) string {
+		return d.Get("zone").(string) + idSeparator + d.Get("name").(string)
+	},
+	"junos_services": func(*schema.ResourceData) string {
+		return "services"
+	},
+	"junos_snmp": func(*schema.ResourceData) string {
+		return "snmp"
+	},
+	"junos_static_route": func(d *schema.ResourceData) string {
+		return d.Get("destination").(string) + idSeparator + d.Get("routing_instance").(string)
+	},
+	"junos_switch_options": func(*schema.ResourceData) string {
+		return "switch_options"
+	},
+	"junos_system": func(*schema.ResourceData) string {
+		return "system"
+	},
+	"junos_system_ntp_server": func(d *schema.ResourceData) string {
+		return d.Get("address").(string)
+	},
+	"junos_system_radius_server": func(d *schema.ResourceData) string {
+		return d.Get("address").(string)
+	},
+	"junos_system_root_authentication": func(*schema.ResourceData) string {
+		return "system_root_authentication"
+	},
+	"junos_system_services_dhcp_localserver_group": func(d *schema.ResourceData) string {
+		return d.Get("name").(string) + idSeparator + d.Get("routing_instance").(string) +
+			idSeparator + d.Get("version").(string)
+	},
+	"junos_system_syslog_file": func(d *schema.ResourceData) string {
+		return d.Get("filename").(string)
+	},
+	"junos_system_syslog_host": func(d *schema.ResourceData) string {
+		return d.Get("host").(string)
+	},
+}
+
+// commitResourceKey is the key of context for the resource which commits.
+type commitResourceKey struct{}
+
+// commitResource is the resource (and its operation) which runs with the context.
+type commitResource struct {
+	resourceType string
+	operation    string
+	id           string
+	resource     *schema.Resource
+	d            *schema.ResourceData
+}
+
+// addResourceCommitComment adds on context of create, update and delete functions of resource
+// the information of resource to render the commit comment template.
+func addResourceCommitComment(name string, resource *schema.Resource) {
+	withResource := func(
+		operation string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics,
+	) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return f(context.WithValue(ctx, commitResourceKey{}, &commitResource{
+				resourceType: name,
+				operation:    operation,
+				resource:     resource,
+				d:            d,
+			}), d, m)
+		}
+	}
+	resource.CreateContext = withResource("create", resource.CreateContext)
+	resource.UpdateContext = withResource("update", resource.UpdateContext)
+	resource.DeleteContext = withResource("delete", resource.DeleteContext)
+}
+
+// validateCommitCommentTemplate checks the placeholders of commit comment template.
+func validateCommitCommentTemplate(template string, vars map[string]string) error {
+	for _, match := range commitCommentPlaceholder.FindAllStringSubmatch(template, -1) {
+		switch name := match[1]; {
+		case name == commitCommentMessage,
+			name == commitCommentOperation,
+			name == commitCommentResourceType,
+			name == commitCommentResourceID,
+			name == commitCommentHost:
+		case strings.HasPrefix(name, commitCommentEnvPrefix) && name != commitCommentEnvPrefix:
+		case strings.HasPrefix(name, commitCommentVarPrefix):
+			if _, ok := vars[strings.TrimPrefix(name, commitCommentVarPrefix)]; !ok {
+				return fmt.Errorf("placeholder %q in 'commit_comment_template' without key in 'commit_comment_vars'",
+					match[0])
+			}
+		default:
+			return fmt.Errorf("unknown placeholder %q in 'commit_comment_template'", match[0])
+		}
+	}
+
+	return nil
+}
+
+// commitComment returns the log message of commit with the commit comment template if set.
+// The resource which commits is read from ctx.
+func (sess *Session) commitComment(ctx context.Context, logMessage string) string {
+	if sess.junosCommentTemplate == "" {
+		return logMessage
+	}
+	var resource *commitResource
+	if ctx != nil {
+		resource, _ = ctx.Value(commitResourceKey{}).(*commitResource)
+	}
+	comment := commitCommentPlaceholder.ReplaceAllStringFunc(sess.junosCommentTemplate, func(s string) string {
+		switch name := commitCommentPlaceholder.FindStringSubmatch(s)[1]; {
+		case name == commitCommentMessage:
+			return logMessage
+		case name == commitCommentHost:
+			return sess.junosIP
+		case strings.HasPrefix(name, commitCommentEnvPrefix):
+			return os.Getenv(strings.TrimPrefix(name, commitCommentEnvPrefix))
+		case strings.HasPrefix(name, commitCommentVarPrefix):
+			return sess.junosCommitCommentVars[strings.TrimPrefix(name, commitCommentVarPrefix)]
+		case resource == nil:
+			return ""
+		case name == commitCommentOperation:
+			return resource.operation
+		case name == commitCommentResourceType:
+			return resource.resourceType
+		case name == commitCommentResourceID:
+			return sess.commitResourceID(resource)
+		default:
+			return ""
+		}
+	})
+
+	return strings.TrimSpace(comment)
+}
+
+// commitResourceID returns the ID of resource which commits.
+// At create, the ID isn't yet set before the commit, so it's the ID set by the create function
+// with setCommitResourceID or computed from the data of resource with commitResourceIDs
+// (or its `name` argument).
+func (sess *Session) commitResourceID(resource *commitResource) string {
+	if id := resource.d.Id(); id != "" || resource.operation != "create" {
+		return id
+	}
+	if resource.id != "" {
+		return resource.id
+	}
+	if resourceID, ok := commitResourceIDs[resource.resourceType]; ok {
+		return resourceID(resource.d)
+	}
+	if _, ok := resource.resource.Schema["name"]; ok {
+		return resource.d.Get("name").(string)
+	}
+	sess.logFile(fmt.Sprintf("[commitComment] resource_id of %s unknown before the commit of create",
+		resource.resourceType))
+
+	return ""
+}
+
+// setCommitResourceID sets the ID of resource which commits with ctx, for a resource with an ID
+// only known with the device at create.
+func setCommitResourceID(ctx context.Context, id string) {
+	if resource, ok := ctx.Value(commitResourceKey{}).(*commitResource); ok {
+		resource.id = id
+	}
+}
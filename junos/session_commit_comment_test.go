@@ -0,0 +1,91 @@
+package junos
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestFakeDeviceCommitComment(t *testing.T) {
+	sess, device := newFakeDeviceSession(t, "vsrx")
+	sess.junosCommentTemplate = "{{operation}} {{ resource_type }} {{resource_id}} " +
+		"pipeline={{env.TESTFAKE_PIPELINE_ID}} sha={{var.sha}} <{{message}}>"
+	sess.junosCommitCommentVars = map[string]string{"sha": "abc"}
+	os.Setenv("TESTFAKE_PIPELINE_ID", "42")
+	defer os.Unsetenv("TESTFAKE_PIPELINE_ID")
+	ctx := context.Background()
+	res := Provider().ResourcesMap["junos_routing_instance"]
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"name": "test",
+	})
+	if diags := res.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if diags := res.DeleteContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	expected := []string{
+		"create junos_routing_instance test pipeline=42 sha=abc <create resource junos_routing_instance>",
+		"delete junos_routing_instance test pipeline=42 sha=abc <delete resource junos_routing_instance>",
+	}
+	commits := device.Commits()
+	if len(commits) != len(expected) {
+		t.Fatalf("unexpected commits %q", commits)
+	}
+	for i := range expected {
+		if commits[i] != expected[i] {
+			t.Errorf("unexpected commit log %q, expected %q", commits[i], expected[i])
+		}
+	}
+
+	for _, template := range []string{"{{unknown}}", "{{var.missing}}", "{{env.}}"} {
+		if err := validateCommitCommentTemplate(template, map[string]string{"sha": "abc"}); err == nil {
+			t.Errorf("template %q valid", template)
+		}
+	}
+}
+
+func TestCommitResourceIDAtCreate(t *testing.T) {
+	sess, _ := newFakeDeviceSession(t, "vsrx")
+	for name, res := range Provider().ResourcesMap {
+		if _, ok := commitResourceIDs[name]; ok {
+			continue
+		}
+		if _, ok := res.Schema["name"]; ok {
+			continue
+		}
+		if name != "junos_interface_st0_unit" {
+			t.Errorf("resource_id of %s unknown before the commit of create", name)
+		}
+	}
+	for name, res := range commitResourceIDs {
+		if _, ok := Provider().ResourcesMap[name]; !ok {
+			t.Errorf("resource_id of unknown resource %s", name)
+		}
+		if res == nil {
+			t.Errorf("resource_id of %s without function", name)
+		}
+	}
+
+	res := Provider().ResourcesMap["junos_static_route"]
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"destination":      "192.0.2.0/24",
+		"routing_instance": "prod",
+	})
+	resource := &commitResource{
+		resourceType: "junos_static_route",
+		operation:    "create",
+		resource:     res,
+		d:            d,
+	}
+	if id := sess.commitResourceID(resource); id != "192.0.2.0/24"+idSeparator+"prod" {
+		t.Errorf("unexpected resource_id %q at create", id)
+	}
+	ctx := context.WithValue(context.Background(), commitResourceKey{}, resource)
+	setCommitResourceID(ctx, "st0.1")
+	if id := sess.commitResourceID(resource); id != "st0.1" {
+		t.Errorf("unexpected resource_id %q set at create", id)
+	}
+}
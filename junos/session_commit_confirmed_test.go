@@ -0,0 +1,83 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// commitConfirmedStubSession returns a session on stub with changes to commit with commit confirmed.
+func commitConfirmedStubSession(t *testing.T) (*Session, *netconfStub, *NetconfObject) {
+	t.Helper()
+	stub := &netconfStub{}
+	sess := &Session{sessionShared: &sessionShared{
+		junosCommitConfirmed: 1,
+		junosConfirmedCheck:  1,
+		// the interval of lock isn't used to check the reachability
+		junosSleepLock: 60,
+		dialTransport:  stub.dial(),
+	}}
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	if err := sess.configLock(jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	if err := sess.configSet([]string{"set system host-name stub"}, jnpr); err != nil {
+		t.Fatalf("configSet: %s", err)
+	}
+
+	return sess, stub, jnpr
+}
+
+func TestCommitConfirmedReachable(t *testing.T) {
+	sess, stub, jnpr := commitConfirmedStubSession(t)
+	if _, err := sess.commitConf("test", jnpr); err != nil {
+		t.Fatalf("commitConf: %s", err)
+	}
+	requests := stub.received()
+	var afterConfirmed []string
+	for i, request := range requests {
+		if strings.Contains(request, "<confirmed/>") {
+			afterConfirmed = requests[i+1:]
+
+			break
+		}
+	}
+	// new session to check reachability then confirming commit
+	expected := []string{rpcSystemInfo, "<close-session/>", "<commit-configuration>"}
+	if len(afterConfirmed) < len(expected) {
+		t.Fatalf("unexpected requests after commit confirmed: %q", afterConfirmed)
+	}
+	for i, v := range expected {
+		if !strings.Contains(afterConfirmed[i], v) {
+			t.Errorf("request %d after commit confirmed doesn't contain %q: %q", i, v, afterConfirmed[i])
+		}
+	}
+}
+
+func TestCommitConfirmedUnreachable(t *testing.T) {
+	sess, stub, jnpr := commitConfirmedStubSession(t)
+	// device unreachable for new sessions after the commit confirmed
+	sess.dialTransport = func() (netconfTransport, error) {
+		return nil, errors.New("connection refused")
+	}
+	start := time.Now()
+	_, err := sess.commitConf("test", jnpr)
+	if err == nil {
+		t.Fatal("commit confirmed without reachability of device")
+	}
+	if !strings.Contains(err.Error(), "rolled back automatically") {
+		t.Errorf("unexpected error: %s", err)
+	}
+	elapsed := time.Since(start)
+	if elapsed < time.Duration(commitConfirmedReconnectAttempts-1)*time.Second || elapsed > 30*time.Second {
+		t.Errorf("reachability checked during %s with an interval of 1 second", elapsed)
+	}
+	if count := stub.count("<commit-configuration>") - stub.count("<confirmed/>"); count != 0 {
+		t.Errorf("%d confirming commits sent without reachability of device", count)
+	}
+}
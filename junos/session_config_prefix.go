@@ -0,0 +1,466 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// junosDefaultsGroup is the group of factory defaults, not configurable.
+	junosDefaultsGroup = "junos-defaults"
+
+	groupsWord         = "groups"
+	logicalSystemsWord = "logical-systems"
+	tenantsWord        = "tenants"
+)
+
+// configPrefixSupport is the set of prefixes supported by the hierarchy of a resource.
+type configPrefixSupport uint8
+
+const (
+	// prefixGroup is a resource which can be configured inside a group.
+	prefixGroup configPrefixSupport = 1 << iota
+	// prefixLogicalSystem is a resource with a hierarchy valid inside a logical system
+	// (routing, policy-options, firewall and security policies, zones, nat and vpn).
+	prefixLogicalSystem
+	// prefixTenant is a resource with a hierarchy valid inside a tenant system
+	// (routing instances and security policies, zones and nat).
+	prefixTenant
+
+	// prefixRoot is a resource which manages the groups and logical systems themselves (or without lines),
+	// its lines and reads are never prefixed by a group, a logical system or a tenant.
+	prefixRoot configPrefixSupport = 0
+)
+
+// configPrefixResources are the prefixes supported by each resource,
+// every resource of provider needs to be classified.
+var configPrefixResources = map[string]configPrefixSupport{ // nolint: gochecknoglobals
+	"junos_access_address_assignment_pool":                       prefixGroup | prefixLogicalSystem,
+	"junos_aggregate_route":                                      prefixGroup | prefixLogicalSystem,
+	"junos_application":                                          prefixGroup | prefixLogicalSystem,
+	"junos_application_set":                                      prefixGroup | prefixLogicalSystem,
+	"junos_bgp_group":                                            prefixGroup | prefixLogicalSystem,
+	"junos_bgp_neighbor":                                         prefixGroup | prefixLogicalSystem,
+	"junos_bridge_domain":                                        prefixGroup,
+	"junos_chassis_cluster":                                      prefixGroup,
+	"junos_commit":                                               prefixRoot,
+	"junos_config_lines":                                         prefixGroup | prefixLogicalSystem | prefixTenant,
+	"junos_eventoptions_destination":                             prefixGroup,
+	"junos_eventoptions_generate_event":                          prefixGroup,
+	"junos_eventoptions_policy":                                  prefixGroup,
+	"junos_evpn":                                                 prefixGroup,
+	"junos_firewall_filter":                                      prefixGroup | prefixLogicalSystem,
+	"junos_firewall_policer":                                     prefixGroup | prefixLogicalSystem,
+	"junos_forwardingoptions_sampling_instance":                  prefixGroup,
+	"junos_generate_route":                                       prefixGroup | prefixLogicalSystem,
+	"junos_group":                                                prefixRoot,
+	"junos_group_dual_system":                                    prefixRoot,
+	"junos_interface":                                            prefixGroup,
+	"junos_interface_logical":                                    prefixGroup | prefixLogicalSystem,
+	"junos_interface_physical":                                   prefixGroup,
+	"junos_interface_physical_disable":                           prefixGroup,
+	"junos_interface_st0_unit":                                   prefixGroup,
+	"junos_logical_system":                                       prefixRoot,
+	"junos_null_commit_file":                                     prefixRoot,
+	"junos_ospf":                                                 prefixGroup | prefixLogicalSystem,
+	"junos_ospf_area":                                            prefixGroup | prefixLogicalSystem,
+	"junos_policyoptions_as_path":                                prefixGroup | prefixLogicalSystem,
+	"junos_policyoptions_as_path_group":                          prefixGroup | prefixLogicalSystem,
+	"junos_policyoptions_community":                              prefixGroup | prefixLogicalSystem,
+	"junos_policyoptions_policy_statement":                       prefixGroup | prefixLogicalSystem,
+	"junos_policyoptions_prefix_list":                            prefixGroup | prefixLogicalSystem,
+	"junos_rib_group":                                            prefixGroup | prefixLogicalSystem,
+	"junos_routing_instance":                                     prefixGroup | prefixLogicalSystem | prefixTenant,
+	"junos_routing_options":                                      prefixGroup | prefixLogicalSystem,
+	"junos_security":                                             prefixGroup,
+	"junos_security_address_book":                                prefixGroup | prefixLogicalSystem | prefixTenant,
+	"junos_security_dynamic_address_feed_server":                 prefixGroup,
+	"junos_security_dynamic_address_name":                        prefixGroup,
+	"junos_security_global_policy":                               prefixGroup | prefixLogicalSystem | prefixTenant,
+	"junos_security_idp_custom_attack":                           prefixGroup,
+	"junos_security_idp_custom_attack_group":                     prefixGroup,
+	"junos_security_idp_policy":                                  prefixGroup,
+	"junos_security_ike_gateway":                                 prefixGroup | prefixLogicalSystem,
+	"junos_security_ike_policy":                                  prefixGroup | prefixLogicalSystem,
+	"junos_security_ike_proposal":                                prefixGroup | prefixLogicalSystem,
+	"junos_security_ipsec_policy":                                prefixGroup | prefixLogicalSystem,
+	"junos_security_ipsec_proposal":                              prefixGroup | prefixLogicalSystem,
+	"junos_security_ipsec_vpn":                                   prefixGroup | prefixLogicalSystem,
+	"junos_security_log_stream":                                  prefixGroup,
+	"junos_security_nat_destination":                             prefixGroup | prefixLogicalSystem | prefixTenant,
+	"junos_security_nat_destination_pool":                        prefixGroup | prefixLogicalSystem | prefixTenant,
+	"junos_security_nat_source":                                  prefixGroup | prefixLogicalSystem | prefixTenant,
+	"junos_security_nat_source_pool":                             prefixGroup | prefixLogicalSystem | prefixTenant,
+	"junos_security_nat_static":                                  prefixGroup | prefixLogicalSystem | prefixTenant,
+	"junos_security_nat_static_rule":                             prefixGroup | prefixLogicalSystem | prefixTenant,
+	"junos_security_policy":                                      prefixGroup | prefixLogicalSystem | prefixTenant,
+	"junos_security_policy_tunnel_pair_policy":                   prefixGroup | prefixLogicalSystem,
+	"junos_security_screen":                                      prefixGroup | prefixLogicalSystem | prefixTenant,
+	"junos_security_screen_whitelist":                            prefixGroup | prefixLogicalSystem | prefixTenant,
+	"junos_security_utm_custom_url_category":                     prefixGroup,
+	"junos_security_utm_custom_url_pattern":                      prefixGroup,
+	"junos_security_utm_policy":                                  prefixGroup,
+	"junos_security_utm_profile_web_filtering_juniper_enhanced":  prefixGroup,
+	"junos_security_utm_profile_web_filtering_juniper_local":     prefixGroup,
+	"junos_security_utm_profile_web_filtering_websense_redirect": prefixGroup,
+	"junos_security_zone":                                        prefixGroup | prefixLogicalSystem | prefixTenant,
+	"junos_security_zone_book_address":                           prefixGroup | prefixLogicalSystem | prefixTenant,
+	"junos_security_zone_book_address_set":                       prefixGroup | prefixLogicalSystem | prefixTenant,
+	"junos_services":                                             prefixGroup,
+	"junos_services_advanced_anti_malware_policy":                prefixGroup,
+	"junos_services_flowmonitoring_vipfix_template":              prefixGroup,
+	"junos_services_proxy_profile":                               prefixGroup,
+	"junos_services_rpm_probe":                                   prefixGroup,
+	"junos_services_security_intelligence_policy":                prefixGroup,
+	"junos_services_security_intelligence_profile":               prefixGroup,
+	"junos_services_ssl_initiation_profile":                      prefixGroup,
+	"junos_services_user_identification_ad_access_domain":        prefixGroup,
+	"junos_services_user_identification_device_identity_profile": prefixGroup,
+	"junos_snmp":                                                 prefixGroup,
+	"junos_snmp_clientlist":                                      prefixGroup,
+	"junos_snmp_community":                                       prefixGroup,
+	"junos_snmp_view":                                            prefixGroup,
+	"junos_static_route":                                         prefixGroup | prefixLogicalSystem,
+	"junos_switch_options":                                       prefixGroup,
+	"junos_system":                                               prefixGroup,
+	"junos_system_login_class":                                   prefixGroup,
+	"junos_system_login_user":                                    prefixGroup,
+	"junos_system_ntp_server":                                    prefixGroup,
+	"junos_system_radius_server":                                 prefixGroup,
+	"junos_system_root_authentication":                           prefixGroup,
+	"junos_system_services_dhcp_localserver_group":               prefixGroup,
+	"junos_system_syslog_file":                                   prefixGroup,
+	"junos_system_syslog_host":                                   prefixGroup,
+	"junos_vlan":                                                 prefixGroup,
+}
+
+// configPrefixCommands are the first words of configuration lines with a hierarchy to prefix.
+var configPrefixCommands = map[string]bool{ // nolint: gochecknoglobals
+	"activate":   true,
+	"deactivate": true,
+	"delete":     true,
+	"insert":     true,
+	"protect":    true,
+	"rename":     true,
+	"set":        true,
+	"unprotect":  true,
+}
+
+// configPrefixCheckKey is the key of context value to check that the logical system
+// or the tenant of session exists when the resource starts its netconf session on create.
+type configPrefixCheckKey struct{}
+
+// configPrefix is the hierarchy where the configuration of a resource is, inside a group
+// and/or a logical system (or a tenant) in place of the top of configuration.
+type configPrefix struct {
+	group         string
+	logicalSystem string
+	tenant        string
+}
+
+// addResourceConfigPrefix adds the `config_group`, `logical_system` and `tenant` arguments on resource
+// (if the resource supports them) to configure it inside a group and/or a logical system (or a tenant)
+// in place of the arguments of provider.
+// The functions of resource run with a session with the prefix of lines and reads.
+// The logical system (or the tenant) need to exist before the creation of resource,
+// it is checked on the netconf session of resource.
+// For import, the id can start with the prefix
+// (`groups <config_group> logical-systems <logical_system> <id>`).
+func addResourceConfigPrefix(name string, resource *schema.Resource) {
+	// resource without arguments (like junos_interface_st0_unit) uses only the prefix of provider
+	withArgs := resource.Schema != nil
+	support := configPrefixResources[name]
+	root := support == prefixRoot
+	withGroup := support&prefixGroup != 0
+	withLogicalSystem := support&prefixLogicalSystem != 0
+	withTenant := support&prefixTenant != 0
+	if withArgs && withGroup {
+		resource.Schema["config_group"] = &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validateNameObjectJunos([]string{junosDefaultsGroup}, 64, formatDefAndDots),
+		}
+	}
+	if withArgs && withLogicalSystem {
+		resource.Schema["logical_system"] = &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validateNameObjectJunos([]string{}, 64, formatDefault),
+		}
+	}
+	if withArgs && withTenant {
+		resource.Schema["tenant"] = &schema.Schema{
+			Type:             schema.TypeString,
+			Optional:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validateNameObjectJunos([]string{}, 64, formatDefault),
+		}
+	}
+	if withArgs && withLogicalSystem && withTenant {
+		resource.Schema["logical_system"].ConflictsWith = []string{"tenant"}
+		resource.Schema["tenant"].ConflictsWith = []string{"logical_system"}
+	}
+	prefixArgs := map[string]bool{
+		"config_group":   withArgs && withGroup,
+		"logical_system": withArgs && withLogicalSystem,
+		"tenant":         withArgs && withTenant,
+	}
+	getArg := func(d *schema.ResourceData, key string) string {
+		if !prefixArgs[key] {
+			return ""
+		}
+
+		return d.Get(key).(string)
+	}
+	sessionPrefix := func(d *schema.ResourceData, m interface{}) (interface{}, error) {
+		sess, ok := m.(*Session)
+		if !ok {
+			return m, nil
+		}
+		if root {
+			return sess.withConfigPrefix(configPrefix{}), nil
+		}
+		prefix := sess.prefix
+		if v := getArg(d, "config_group"); v != "" {
+			prefix.group = v
+		}
+		if getArg(d, "logical_system") != "" || getArg(d, "tenant") != "" {
+			prefix.logicalSystem, prefix.tenant = getArg(d, "logical_system"), getArg(d, "tenant")
+		}
+		switch {
+		case prefix.logicalSystem != "" && prefix.tenant != "":
+			return nil, fmt.Errorf("%s can't be configured inside logical system %q and tenant %q",
+				name, prefix.logicalSystem, prefix.tenant)
+		case prefix.group != "" && !withGroup:
+			return nil, fmt.Errorf("%s can't be configured inside group %q", name, prefix.group)
+		case prefix.logicalSystem != "" && !withLogicalSystem:
+			return nil, fmt.Errorf("%s can't be configured inside logical system %q", name, prefix.logicalSystem)
+		case prefix.tenant != "" && !withTenant:
+			return nil, fmt.Errorf("%s can't be configured inside tenant %q", name, prefix.tenant)
+		}
+
+		return sess.withConfigPrefix(prefix), nil
+	}
+	withPrefix := func(
+		f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, create bool,
+	) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			m, err := sessionPrefix(d, m)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if create {
+				ctx = context.WithValue(ctx, configPrefixCheckKey{}, true)
+			}
+
+			return f(ctx, d, m)
+		}
+	}
+	resource.CreateContext = withPrefix(resource.CreateContext, true)
+	resource.ReadContext = withPrefix(resource.ReadContext, false)
+	resource.UpdateContext = withPrefix(resource.UpdateContext, false)
+	resource.DeleteContext = withPrefix(resource.DeleteContext, false)
+	if resource.Importer != nil && resource.Importer.StateContext != nil {
+		importState := resource.Importer.StateContext
+		resource.Importer.StateContext = func(
+			ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			if !root {
+				prefix, id := configPrefixFromID(d.Id())
+				for key, value := range map[string]string{
+					"config_group":   prefix.group,
+					"logical_system": prefix.logicalSystem,
+					"tenant":         prefix.tenant,
+				} {
+					if value == "" {
+						continue
+					}
+					if !prefixArgs[key] {
+						return nil, fmt.Errorf("%s doesn't support %s in id %q", name, key, d.Id())
+					}
+					if tfErr := d.Set(key, value); tfErr != nil {
+						panic(tfErr)
+					}
+				}
+				d.SetId(id)
+			}
+			m, err := sessionPrefix(d, m)
+			if err != nil {
+				return nil, err
+			}
+
+			return importState(ctx, d, m)
+		}
+	}
+}
+
+// withConfigPrefix returns a session which shares the connection and the states of sess
+// with prefix for the lines and the reads of configuration.
+func (sess *Session) withConfigPrefix(prefix configPrefix) *Session {
+	if sess.prefix == prefix {
+		return sess
+	}
+
+	return &Session{sessionShared: sess.sessionShared, prefix: prefix}
+}
+
+// configPrefixFromID returns the prefix at the start of an import id
+// (`groups <group> logical-systems <logical_system> <id>` or `tenants <tenant> <id>`)
+// and the id of resource without it.
+func configPrefixFromID(id string) (configPrefix, string) {
+	var prefix configPrefix
+	words := strings.Split(id, " ")
+	for len(words) > 2 {
+		switch words[0] {
+		case groupsWord:
+			prefix.group = words[1]
+		case logicalSystemsWord:
+			prefix.logicalSystem = words[1]
+		case tenantsWord:
+			prefix.tenant = words[1]
+		default:
+			return prefix, strings.Join(words, " ")
+		}
+		words = words[2:]
+	}
+
+	return prefix, strings.Join(words, " ")
+}
+
+// words returns the words of hierarchy with the group, the logical system and the tenant.
+func (prefix configPrefix) words() []string {
+	words := make([]string, 0, 4)
+	if prefix.group != "" {
+		words = append(words, groupsWord, prefix.group)
+	}
+	if prefix.logicalSystem != "" {
+		words = append(words, logicalSystemsWord, prefix.logicalSystem)
+	}
+	if prefix.tenant != "" {
+		words = append(words, tenantsWord, prefix.tenant)
+	}
+
+	return words
+}
+
+// insidePrefix returns true if the hierarchy is already inside a group, a logical system or a tenant.
+func insidePrefix(hierarchy string) bool {
+	switch strings.SplitN(strings.TrimSpace(hierarchy), " ", 2)[0] {
+	case groupsWord, logicalSystemsWord, tenantsWord:
+		return true
+	default:
+		return false
+	}
+}
+
+// lines returns the configuration lines (`set`, `delete`, ...) with the statements inside the prefix.
+// The lines already inside a group, a logical system or a tenant and the other lines are not modified.
+func (prefix configPrefix) lines(lines []string) []string {
+	words := prefix.words()
+	if len(words) == 0 {
+		return lines
+	}
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		lineSplit := strings.SplitN(line, " ", 2)
+		if len(lineSplit) != 2 || !configPrefixCommands[lineSplit[0]] || insidePrefix(lineSplit[1]) {
+			result = append(result, line)
+
+			continue
+		}
+		result = append(result, lineSplit[0]+" "+strings.Join(words, " ")+" "+lineSplit[1])
+	}
+
+	return result
+}
+
+// command returns the `show configuration` command with the hierarchy inside the prefix.
+// The output of command with `display set` (not relative) has the prefix in lines.
+func (prefix configPrefix) command(cmd string) string {
+	words := prefix.words()
+	if len(words) == 0 || !strings.HasPrefix(cmd, showConfigurationWord) {
+		return cmd
+	}
+	hierarchy := strings.TrimPrefix(cmd, showConfigurationWord)
+	if hierarchy != "" && !strings.HasPrefix(hierarchy, " ") {
+		return cmd
+	}
+	if insidePrefix(hierarchy) {
+		return cmd
+	}
+
+	return showConfigurationWord + " " + strings.Join(words, " ") + hierarchy
+}
+
+// setLine returns the start of `set` line for statement inside the prefix,
+// as in the output of `show configuration` command with `display set` (not relative).
+func (prefix configPrefix) setLine(statement string) string {
+	return strings.Join(append(append([]string{"set"}, prefix.words()...), statement), " ")
+}
+
+// path returns the path of get-configuration with the hierarchy inside the prefix.
+func (prefix configPrefix) path(path []configXMLElement) []configXMLElement {
+	words := prefix.words()
+	if len(words) == 0 || (len(path) > 0 && insidePrefix(path[0].tag)) {
+		return path
+	}
+	result := make([]configXMLElement, 0, len(words)/2+len(path))
+	for i := 0; i+1 < len(words); i += 2 {
+		result = append(result, configXMLElement{tag: words[i], name: words[i+1]})
+	}
+
+	return append(result, path...)
+}
+
+// checkConfigPrefixExists returns an error if the logical system or the tenant of session
+// doesn't exist on device when the resource is created (ctx with configPrefixCheckKey).
+// The check reads the top of configuration on the netconf session started by the resource.
+func (sess *Session) checkConfigPrefixExists(ctx context.Context, jnpr *NetconfObject) error {
+	if check, _ := ctx.Value(configPrefixCheckKey{}).(bool); !check ||
+		(sess.prefix.logicalSystem == "" && sess.prefix.tenant == "") {
+		return nil
+	}
+	rootSess := sess.withConfigPrefix(configPrefix{})
+	if sess.prefix.logicalSystem != "" {
+		logicalSystemExists, err := checkLogicalSystemExists(sess.prefix.logicalSystem, rootSess, jnpr)
+		if err != nil {
+			return err
+		}
+		if !logicalSystemExists {
+			return fmt.Errorf("logical system %v doesn't exist", sess.prefix.logicalSystem)
+		}
+	}
+	if sess.prefix.tenant != "" {
+		tenantExists, err := checkTenantExists(sess.prefix.tenant, rootSess, jnpr)
+		if err != nil {
+			return err
+		}
+		if !tenantExists {
+			return fmt.Errorf("tenant %v doesn't exist", sess.prefix.tenant)
+		}
+	}
+
+	return nil
+}
+
+func checkTenantExists(tenant string, m interface{}, jnprSess *NetconfObject) (bool, error) {
+	sess := m.(*Session)
+	showConfig, err := sess.command("show configuration tenants "+tenant+" | display set", jnprSess)
+	if err != nil {
+		return false, err
+	}
+	if showConfig == emptyWord {
+		return false, nil
+	}
+
+	return true, nil
+}
@@ -0,0 +1,310 @@
+package junos
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestConfigPrefix(t *testing.T) {
+	prefix := configPrefix{group: "standards", logicalSystem: "ls1"}
+	lines := prefix.lines([]string{
+		"set routing-instances test instance-type virtual-router",
+		"delete routing-instances test",
+		"set groups other routing-instances test",
+		"load merge terminal",
+	})
+	expected := []string{
+		"set groups standards logical-systems ls1 routing-instances test instance-type virtual-router",
+		"delete groups standards logical-systems ls1 routing-instances test",
+		"set groups other routing-instances test",
+		"load merge terminal",
+	}
+	if !reflect.DeepEqual(lines, expected) {
+		t.Errorf("unexpected lines %q, expected %q", lines, expected)
+	}
+	for cmd, expected := range map[string]string{
+		"show configuration routing-instances test | display set relative": "show configuration " +
+			"groups standards logical-systems ls1 routing-instances test | display set relative",
+		"show configuration | display set":              "show configuration groups standards logical-systems ls1 | display set",
+		"show configuration groups other | display set": "show configuration groups other | display set",
+		"show configurations":                           "show configurations",
+		"show interfaces st0 terse":                     "show interfaces st0 terse",
+	} {
+		if result := prefix.command(cmd); result != expected {
+			t.Errorf("unexpected command for %q: %q, expected %q", cmd, result, expected)
+		}
+	}
+	if line := prefix.setLine("interfaces "); line != "set groups standards logical-systems ls1 interfaces " {
+		t.Errorf("unexpected set line %q", line)
+	}
+	path := prefix.path([]configXMLElement{{tag: "routing-options"}})
+	if len(path) != 3 || path[0].name != "standards" || path[1].tag != "logical-systems" {
+		t.Errorf("unexpected path %v", path)
+	}
+	for id, expected := range map[string]struct {
+		prefix configPrefix
+		id     string
+	}{
+		"test":                              {id: "test"},
+		"groups standards test":             {prefix: configPrefix{group: "standards"}, id: "test"},
+		"tenants t1 192.0.2.0/24_-_default": {prefix: configPrefix{tenant: "t1"}, id: "192.0.2.0/24_-_default"},
+		"groups g1 logical-systems ls1 a b": {prefix: configPrefix{group: "g1", logicalSystem: "ls1"}, id: "a b"},
+		"groups standards":                  {id: "groups standards"},
+		"protocols bgp group CORE":          {id: "protocols bgp group CORE"},
+	} {
+		prefix, resultID := configPrefixFromID(id)
+		if prefix != expected.prefix || resultID != expected.id {
+			t.Errorf("unexpected prefix %v and id %q from %q", prefix, resultID, id)
+		}
+	}
+}
+
+func TestFakeDeviceConfigGroup(t *testing.T) {
+	sess, device := newFakeDeviceSession(t, "vsrx")
+	ctx := context.Background()
+	provider := Provider()
+	group := provider.ResourcesMap["junos_group"]
+	dGroup := schema.TestResourceDataRaw(t, group.Schema, map[string]interface{}{
+		"name":                     "standards",
+		"apply_groups_hierarchies": []interface{}{"interfaces"},
+		"when": []interface{}{map[string]interface{}{
+			"model": "vsrx",
+		}},
+	})
+	if diags := group.CreateContext(ctx, dGroup, sess); diags.HasError() {
+		t.Fatalf("create group: %v", diags)
+	}
+	res := provider.ResourcesMap["junos_routing_instance"]
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"name":         "test",
+		"config_group": "standards",
+	})
+	if diags := res.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	committed := strings.Join(device.Committed(), "\n")
+	for _, line := range []string{
+		"set groups standards when model vsrx",
+		"set apply-groups standards",
+		"set interfaces apply-groups standards",
+		"set groups standards routing-instances test instance-type virtual-router",
+	} {
+		if !strings.Contains(committed, line+"\n") && !strings.HasSuffix(committed, line) {
+			t.Errorf("line %q not committed in configuration:\n%s", line, committed)
+		}
+	}
+	if strings.Contains(committed, "set routing-instances") {
+		t.Errorf("instance committed outside group:\n%s", committed)
+	}
+	if diags := res.ReadContext(ctx, d, sess); diags.HasError() || d.Id() == "" {
+		t.Fatalf("read: %v (id %q)", diags, d.Id())
+	}
+	dImport := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{})
+	dImport.SetId("groups standards test")
+	if _, err := res.Importer.StateContext(ctx, dImport, sess); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if dImport.Id() != "test" || dImport.Get("config_group").(string) != "standards" {
+		t.Errorf("unexpected import: id %q, config_group %q", dImport.Id(), dImport.Get("config_group"))
+	}
+	if diags := group.ReadContext(ctx, dGroup, sess); diags.HasError() {
+		t.Fatalf("read group: %v", diags)
+	}
+	if !dGroup.Get("apply_groups").(bool) ||
+		len(dGroup.Get("apply_groups_hierarchies").([]interface{})) != 1 ||
+		dGroup.Get("when.0.model").(string) != "vsrx" {
+		t.Errorf("unexpected group after read: apply_groups %v, hierarchies %v, when %v",
+			dGroup.Get("apply_groups"), dGroup.Get("apply_groups_hierarchies"), dGroup.Get("when"))
+	}
+
+	// group of provider
+	sess.prefix.group = "provider"
+	d = schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"name": "test2",
+	})
+	if diags := res.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create with group of provider: %v", diags)
+	}
+	if committed := strings.Join(device.Committed(), "\n"); !strings.Contains(committed,
+		"set groups provider routing-instances test2 instance-type virtual-router") {
+		t.Errorf("instance not committed in group of provider:\n%s", committed)
+	}
+	if diags := group.DeleteContext(ctx, dGroup, sess); diags.HasError() {
+		t.Fatalf("delete group: %v", diags)
+	}
+	if committed := strings.Join(device.Committed(), "\n"); strings.Contains(committed, "standards") {
+		t.Errorf("group not deleted:\n%s", committed)
+	}
+}
+
+func TestFakeDeviceLogicalSystem(t *testing.T) {
+	sess, device := newFakeDeviceSession(t, "vsrx")
+	ctx := context.Background()
+	provider := Provider()
+	res := provider.ResourcesMap["junos_routing_instance"]
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"name":           "test",
+		"logical_system": "ls1",
+	})
+	if diags := res.CreateContext(ctx, d, sess); !diags.HasError() {
+		t.Fatal("create in logical system not exists without error")
+	}
+	logicalSystem := provider.ResourcesMap["junos_logical_system"]
+	dLogicalSystem := schema.TestResourceDataRaw(t, logicalSystem.Schema, map[string]interface{}{
+		"name": "ls1",
+		"security_profile": []interface{}{map[string]interface{}{
+			"name": "sp1",
+			"resource": []interface{}{map[string]interface{}{
+				"type":     "policy",
+				"maximum":  100,
+				"reserved": 10,
+			}},
+		}},
+	})
+	if diags := logicalSystem.CreateContext(ctx, dLogicalSystem, sess); diags.HasError() {
+		t.Fatalf("create logical system: %v", diags)
+	}
+	dial := sess.dialTransport
+	dials := 0
+	sess.dialTransport = func() (netconfTransport, error) {
+		dials++
+
+		return dial()
+	}
+	if diags := res.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if dials != 1 {
+		t.Errorf("create in logical system opened %d netconf sessions, expected 1", dials)
+	}
+	committed := strings.Join(device.Committed(), "\n")
+	for _, line := range []string{
+		"set system security-profile sp1 logical-system ls1",
+		"set system security-profile sp1 policy maximum 100",
+		"set system security-profile sp1 policy reserved 10",
+		"set logical-systems ls1 routing-instances test instance-type virtual-router",
+	} {
+		if !strings.Contains(committed, line+"\n") && !strings.HasSuffix(committed, line) {
+			t.Errorf("line %q not committed in configuration:\n%s", line, committed)
+		}
+	}
+	if strings.Contains(committed, "set routing-instances") {
+		t.Errorf("instance committed outside logical system:\n%s", committed)
+	}
+	if diags := res.ReadContext(ctx, d, sess); diags.HasError() || d.Id() == "" {
+		t.Fatalf("read: %v (id %q)", diags, d.Id())
+	}
+	dImport := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{})
+	dImport.SetId("logical-systems ls1 test")
+	if _, err := res.Importer.StateContext(ctx, dImport, sess); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if dImport.Id() != "test" || dImport.Get("logical_system").(string) != "ls1" {
+		t.Errorf("unexpected import: id %q, logical_system %q", dImport.Id(), dImport.Get("logical_system"))
+	}
+	snmpCommunity := provider.ResourcesMap["junos_snmp_community"]
+	dImport = schema.TestResourceDataRaw(t, snmpCommunity.Schema, map[string]interface{}{})
+	dImport.SetId("logical-systems ls1 public")
+	if _, err := snmpCommunity.Importer.StateContext(ctx, dImport, sess); err == nil {
+		t.Error("import of junos_snmp_community inside logical system without error")
+	}
+	if diags := logicalSystem.ReadContext(ctx, dLogicalSystem, sess); diags.HasError() {
+		t.Fatalf("read logical system: %v", diags)
+	}
+	if dLogicalSystem.Get("security_profile.0.name").(string) != "sp1" ||
+		dLogicalSystem.Get("security_profile.0.resource.0.maximum").(int) != 100 ||
+		dLogicalSystem.Get("security_profile.0.resource.0.reserved").(int) != 10 {
+		t.Errorf("unexpected security_profile after read: %v", dLogicalSystem.Get("security_profile"))
+	}
+
+	// tenant of provider
+	device.LoadCommitted("set tenants t1")
+	sess.prefix.tenant = "t1"
+	d = schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"name": "test2",
+	})
+	if diags := res.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create with tenant of provider: %v", diags)
+	}
+	if committed := strings.Join(device.Committed(), "\n"); !strings.Contains(committed,
+		"set tenants t1 routing-instances test2 instance-type virtual-router") {
+		t.Errorf("instance not committed in tenant of provider:\n%s", committed)
+	}
+	if diags := logicalSystem.DeleteContext(ctx, dLogicalSystem, sess); diags.HasError() {
+		t.Fatalf("delete logical system: %v", diags)
+	}
+	if committed := strings.Join(device.Committed(), "\n"); strings.Contains(committed, "ls1") ||
+		strings.Contains(committed, "sp1") {
+		t.Errorf("logical system not deleted:\n%s", committed)
+	}
+}
+
+func TestFakeDeviceConfigPrefixDisplaySet(t *testing.T) {
+	sess, device := newFakeDeviceSession(t, "vsrx")
+	device.LoadCommitted(
+		"set groups g1 interfaces ge-0/0/3 description test",
+		"set groups g1 security policies from-zone a to-zone b policy p1 then permit tunnel pair-policy p2",
+		"set groups g1 security policies from-zone b to-zone a policy p2 then permit tunnel pair-policy p1",
+	)
+	sess = sess.withConfigPrefix(configPrefix{group: "g1"})
+	jnprSess, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %v", err)
+	}
+	defer sess.closeSession(jnprSess)
+	pairPolicy, err := readSecurityPolicyTunnelPairPolicy("a"+idSeparator+"p1"+idSeparator+"b"+idSeparator+"p2",
+		sess, jnprSess)
+	if err != nil {
+		t.Fatalf("read tunnel pair policy: %v", err)
+	}
+	if pairPolicy.policyAtoB != "p1" || pairPolicy.policyBtoA != "p2" {
+		t.Errorf("unexpected tunnel pair policy in group: %+v", pairPolicy)
+	}
+	interfaceID, err := searchInterfacePhysicalID("", "ge-0/0/3 ", sess, jnprSess)
+	if err != nil {
+		t.Fatalf("search interface: %v", err)
+	}
+	if interfaceID != "ge-0/0/3" {
+		t.Errorf("unexpected interface %q found in group", interfaceID)
+	}
+}
+
+func TestConfigPrefixResources(t *testing.T) {
+	provider := Provider()
+	for name := range configPrefixResources {
+		if _, ok := provider.ResourcesMap[name]; !ok {
+			t.Errorf("resource %s in configPrefixResources doesn't exist", name)
+		}
+	}
+	for name := range provider.ResourcesMap {
+		if _, ok := configPrefixResources[name]; !ok {
+			t.Errorf("resource %s without classification in configPrefixResources", name)
+		}
+	}
+	for _, name := range []string{
+		"junos_chassis_cluster", "junos_security_idp_policy", "junos_snmp", "junos_system",
+	} {
+		res := provider.ResourcesMap[name]
+		if res.Schema["logical_system"] != nil || res.Schema["tenant"] != nil {
+			t.Errorf("resource %s accepts a logical system or a tenant", name)
+		}
+		if res.Schema["config_group"] == nil {
+			t.Errorf("resource %s doesn't accept a group", name)
+		}
+	}
+	if res := provider.ResourcesMap["junos_system_login_class"]; res.Schema["logical_system"].ForceNew {
+		t.Error("logical_system argument of junos_system_login_class replaced")
+	}
+
+	sess, _ := newFakeDeviceSession(t, "vsrx")
+	sess.prefix.logicalSystem = "ls1"
+	res := provider.ResourcesMap["junos_snmp"]
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{})
+	if diags := res.CreateContext(context.Background(), d, sess); !diags.HasError() {
+		t.Error("create of junos_snmp inside logical system of provider without error")
+	}
+}
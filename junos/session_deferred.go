@@ -0,0 +1,355 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// deferredCommit stores changes of resources in a candidate configuration locked
+// on a dedicated session to commit them once.
+type deferredCommit struct {
+	holding         bool
+	candidateLoaded bool
+	destroyed       bool
+	resource        sync.Mutex
+	mutex           sync.Mutex
+	err             error
+	jnpr            *NetconfObject
+	accepted        []deferredChange
+	current         []string
+	candidate       []string
+	planned         []string
+}
+
+// deferredChange is the lines loaded in candidate configuration by a resource.
+type deferredChange struct {
+	logMessage string
+	lines      []string
+}
+
+// hasChanges returns true if the candidate configuration has changes not committed.
+func (d *deferredCommit) hasChanges() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return len(d.accepted) > 0 || len(d.current) > 0
+}
+
+// uncommitted returns the changes accepted in candidate configuration and not yet committed.
+func (d *deferredCommit) uncommitted() []string {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	changes := make([]string, 0, len(d.accepted))
+	for _, change := range d.accepted {
+		changes = append(changes, change.logMessage)
+	}
+
+	return changes
+}
+
+// planChange records a resource with changes at plan, so the junos_commit resource
+// planned after it has a change and commits in the same Terraform run.
+func (d *deferredCommit) planChange(resourceType string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.planned = append(d.planned, resourceType)
+}
+
+// hasPlannedChanges returns true if resources have changes at plan.
+func (d *deferredCommit) hasPlannedChanges() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return len(d.planned) > 0
+}
+
+// destroy stops deferring the changes of next resources: without junos_commit resource
+// to commit them, the resources commit their changes themselves.
+func (d *deferredCommit) destroy() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.destroyed = true
+}
+
+// isDestroyed returns true if the junos_commit resource has been destroyed.
+func (d *deferredCommit) isDestroyed() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.destroyed
+}
+
+// lock waits the other resources and locks the candidate configuration on the dedicated session.
+// The rpc of dedicated session are canceled with ctx until the next lock.
+func (d *deferredCommit) lock(ctx context.Context, sess *Session) error {
+	d.resource.Lock()
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.err != nil {
+		d.resource.Unlock()
+
+		return d.err
+	}
+	if d.jnpr == nil {
+		jnpr, err := sess.openNetconfObject(ctx)
+		if err != nil {
+			if jnpr != nil {
+				jnpr.closeTransport()
+			}
+			d.resource.Unlock()
+
+			return fmt.Errorf("failed to open dedicated session for deferred commit : %w", err)
+		}
+		if err := sess.waitConfigLock(jnpr); err != nil {
+			jnpr.closeTransport()
+			d.resource.Unlock()
+
+			return err
+		}
+		d.jnpr = jnpr
+	}
+	d.jnpr.ctx = ctx
+	d.holding = true
+
+	return nil
+}
+
+// configSet loads lines in candidate configuration on the dedicated session.
+func (d *deferredCommit) configSet(sess *Session, cmd []string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.err != nil {
+		return d.err
+	}
+	d.current = append(d.current, cmd...)
+	d.candidateLoaded = false
+	var message string
+	err := sess.retry(d.jnpr, "configSet", func() (err error) {
+		message, err = d.jnpr.netconfConfigSet(cmd)
+
+		return err
+	})
+	sleepShort(sess.junosSleepShort)
+	sess.logFile(fmt.Sprintf("[configSet] deferred cmd: %q", cmd))
+	sess.logFile(fmt.Sprintf("[configSet] deferred message: %q", message))
+	if err != nil {
+		sess.logFile(fmt.Sprintf("[configSet] deferred err: %q", err))
+
+		return err
+	}
+	d.jnpr.pending = append(d.jnpr.pending, cmd...)
+
+	return nil
+}
+
+// check validates the candidate configuration with the lines of resource and release
+// the candidate for other resources.
+// When check fails, the candidate is kept for resource until configClear.
+func (d *deferredCommit) check(sess *Session, logMessage string) (_warnings []error, _err error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.err != nil {
+		return []error{}, d.err
+	}
+	sess.logFile(fmt.Sprintf("[commitConf] deferred commit check for %q", logMessage))
+	var warns []error
+	err := sess.retry(d.jnpr, "commit check", func() (err error) {
+		warns, err = d.jnpr.netconfCommitCheck()
+
+		return err
+	})
+	sleepShort(sess.junosSleepShort)
+	if err != nil {
+		sess.logFile(fmt.Sprintf("[commitConf] deferred commit check error: %q", err))
+
+		return warns, fmt.Errorf("commit check failed for %s : %w", logMessage, err)
+	}
+	if len(d.current) > 0 {
+		d.accepted = append(d.accepted, deferredChange{logMessage: logMessage, lines: d.current})
+	}
+	d.current = nil
+	if d.holding {
+		d.holding = false
+		d.resource.Unlock()
+	}
+
+	return warns, nil
+}
+
+// clear discards lines of resource in candidate configuration (reload lines of other resources)
+// and release the candidate for other resources.
+func (d *deferredCommit) clear(sess *Session) (errs []error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if !d.holding {
+		return []error{}
+	}
+	d.holding = false
+	defer d.resource.Unlock()
+	if d.err != nil || len(d.current) == 0 {
+		d.current = nil
+
+		return []error{}
+	}
+	d.current = nil
+	d.candidateLoaded = false
+	// reload the changes of other resources even if the context of resource is canceled
+	d.jnpr.ctx = context.Background()
+	errs = append(errs, d.jnpr.netconfConfigClear()...)
+	sleepShort(sess.junosSleepShort)
+	sess.logFile("[configClear] deferred config clear")
+	lines := make([]string, 0)
+	for _, change := range d.accepted {
+		lines = append(lines, change.lines...)
+	}
+	d.jnpr.pending = lines
+	if len(lines) > 0 {
+		if _, err := d.jnpr.netconfConfigSet(lines); err != nil {
+			d.err = fmt.Errorf("failed to reload deferred changes after config clear : %w", err)
+			errs = append(errs, d.err)
+		}
+		sleepShort(sess.junosSleepShort)
+		sess.logFile(fmt.Sprintf("[configClear] deferred reload: %q", lines))
+	}
+
+	return errs
+}
+
+// command serves `show configuration` commands with the candidate configuration
+// of the dedicated session.
+func (d *deferredCommit) command(ctx context.Context, sess *Session, cmd string) (string, bool, error) {
+	filter, ok := newSetLinesFilter(cmd)
+	if !ok {
+		return "", false, nil
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.err != nil {
+		return "", true, d.err
+	}
+	if !d.candidateLoaded {
+		holderCtx := d.jnpr.ctx
+		d.jnpr.ctx = ctx
+		candidate, err := d.jnpr.netconfCandidateSetLines()
+		d.jnpr.ctx = holderCtx
+		sleepShort(sess.junosSleepShort)
+		if err != nil {
+			sess.logFile(fmt.Sprintf("[command] deferred candidate err: %q", err))
+
+			return "", true, err
+		}
+		d.candidate = candidate
+		d.candidateLoaded = true
+	}
+	read := filter.output(d.candidate)
+	sess.logFile(fmt.Sprintf("[command] deferred cmd: %q", cmd))
+	sess.logFile(fmt.Sprintf("[command] deferred read: %q", read))
+
+	return read, true, nil
+}
+
+// commit commits all accepted changes in candidate configuration, closes the dedicated session
+// and returns the committed changes.
+func (d *deferredCommit) commit(
+	ctx context.Context, sess *Session, logMessage string) (_changes []string, _warnings []error, _err error) {
+	d.resource.Lock()
+	defer d.resource.Unlock()
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.jnpr == nil {
+		if d.err != nil {
+			err := d.err
+			d.err = nil
+
+			return []string{}, []error{}, err
+		}
+
+		return []string{}, []error{}, nil
+	}
+	defer func() {
+		sess.closeDedicatedSession(d.jnpr)
+		d.jnpr = nil
+		d.err = nil
+		d.accepted = nil
+		d.candidate = nil
+		d.candidateLoaded = false
+	}()
+	if d.err != nil {
+		return []string{}, []error{}, d.err
+	}
+	if len(d.accepted) == 0 {
+		return []string{}, []error{}, nil
+	}
+	d.jnpr.ctx = ctx
+	resources := make([]string, 0, len(d.accepted))
+	for _, change := range d.accepted {
+		resources = append(resources, change.logMessage)
+	}
+	if logMessage == "" {
+		logMessage = fmt.Sprintf("commit deferred changes of %d resources", len(d.accepted))
+	}
+	logMessage = sess.commitComment(ctx, logMessage)
+	sess.logFile(fmt.Sprintf("[commitConf] deferred commit %q for %q", logMessage, resources))
+	warns := sess.showCompare(logMessage, d.jnpr)
+	warns = append(warns, sess.synchronizeWarning(d.jnpr)...)
+	warnsCommit, err := sess.commitWithConfirm(logMessage, d.jnpr)
+	warns = append(warns, warnsCommit...)
+	if err != nil {
+		sess.logFile(fmt.Sprintf("[commitConf] deferred commit error: %q", err))
+		_ = d.jnpr.netconfConfigClear()
+
+		return []string{}, warns, fmt.Errorf("deferred commit failed, changes discarded for %s : %w",
+			strings.Join(resources, ", "), err)
+	}
+
+	return resources, warns, nil
+}
+
+// discard clears all changes in candidate configuration and closes the dedicated session.
+func (d *deferredCommit) discard(sess *Session) {
+	d.resource.Lock()
+	defer d.resource.Unlock()
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.jnpr == nil {
+		d.err = nil
+
+		return
+	}
+	sess.logFile(fmt.Sprintf("[configClear] deferred changes of %d resources discarded", len(d.accepted)))
+	for _, err := range d.jnpr.netconfConfigClear() {
+		sess.logFile(fmt.Sprintf("[configClear] deferred config clear err: %q", err))
+	}
+	sess.closeDedicatedSession(d.jnpr)
+	d.jnpr = nil
+	d.err = nil
+	d.accepted = nil
+	d.current = nil
+	d.candidate = nil
+	d.candidateLoaded = false
+}
+
+// commandXML runs a rpc on the dedicated session to read the candidate configuration.
+func (d *deferredCommit) commandXML(ctx context.Context, sess *Session, rpc string) (string, error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.err != nil {
+		return "", d.err
+	}
+	holderCtx := d.jnpr.ctx
+	d.jnpr.ctx = ctx
+	read, err := d.jnpr.netconfCommandXML(rpc)
+	d.jnpr.ctx = holderCtx
+	sleepShort(sess.junosSleepShort)
+	sess.logFile(fmt.Sprintf("[commandXML] deferred cmd: %q", rpc))
+	sess.logFile(fmt.Sprintf("[commandXML] deferred read: %q", read))
+	if err != nil {
+		sess.logFile(fmt.Sprintf("[commandXML] deferred err: %q", err))
+
+		return "", err
+	}
+
+	return read, nil
+}
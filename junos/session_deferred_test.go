@@ -0,0 +1,82 @@
+package junos
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// countCommits returns the number of commits (without the commit checks) received by stub.
+func countCommits(stub *netconfStub) int {
+	return stub.count("<commit-configuration>") - stub.count(rpcCommitCheck)
+}
+
+// newDeferredStubSession returns a session with deferred commit on a dedicated session of stub.
+func newDeferredStubSession(t *testing.T) (*Session, *netconfStub) {
+	t.Helper()
+	stub := &netconfStub{}
+
+	return &Session{sessionShared: &sessionShared{deferred: &deferredCommit{jnpr: stub.open(t)}}}, stub
+}
+
+// deferStubChange loads a line in candidate configuration of deferred commit like a resource.
+func deferStubChange(t *testing.T, sess *Session, stub *netconfStub, line string) {
+	t.Helper()
+	jnpr := stub.open(t)
+	defer sess.closeSession(jnpr)
+	if err := sess.configLock(jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	if err := sess.configSet([]string{line}, jnpr); err != nil {
+		t.Fatalf("configSet: %s", err)
+	}
+	if _, err := sess.commitConf("create resource "+line, jnpr); err != nil {
+		t.Fatalf("commitConf: %s", err)
+	}
+}
+
+func TestDeferredCommitNotAtStop(t *testing.T) {
+	sess, stub := newDeferredStubSession(t)
+	deferStubChange(t, sess, stub, "set system host-name stub")
+	if changes := sess.deferred.uncommitted(); len(changes) != 1 {
+		t.Fatalf("unexpected uncommitted changes %q", changes)
+	}
+	sess.stop()
+	if count := countCommits(stub); count != 0 {
+		t.Errorf("%d commits when provider stops, want 0", count)
+	}
+	if count := stub.count(rpcClearCandidate); count == 0 {
+		t.Error("deferred changes not discarded when provider stops")
+	}
+	if changes := sess.deferred.uncommitted(); len(changes) != 0 {
+		t.Errorf("uncommitted changes %q after stop", changes)
+	}
+}
+
+func TestDeferredCommitChanges(t *testing.T) {
+	sess, stub := newDeferredStubSession(t)
+	deferStubChange(t, sess, stub, "set system host-name stub")
+	deferStubChange(t, sess, stub, "set system location building stub")
+	changes, _, err := sess.commitDeferred(context.Background(), "")
+	if err != nil {
+		t.Fatalf("commitDeferred: %s", err)
+	}
+	if len(changes) != 2 || !strings.Contains(changes[1], "location") {
+		t.Errorf("unexpected committed changes %q", changes)
+	}
+	if count := countCommits(stub); count != 1 {
+		t.Errorf("%d commits for deferred changes, want 1", count)
+	}
+}
+
+func TestDeferredCommitDestroyed(t *testing.T) {
+	sess, stub := newDeferredStubSession(t)
+	sess.deferred.destroy()
+	deferStubChange(t, sess, stub, "set system host-name stub")
+	if count := countCommits(stub); count != 1 {
+		t.Errorf("%d commits for a change after the destroy of junos_commit, want 1", count)
+	}
+	if changes := sess.deferred.uncommitted(); len(changes) != 0 {
+		t.Errorf("change deferred after the destroy of junos_commit: %q", changes)
+	}
+}
@@ -0,0 +1,141 @@
+package junos
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/jeremmfr/terraform-provider-junos/junos/internal/setconfig"
+)
+
+const offlineNotAvailable = "not available without device in offline mode"
+
+// offlineConfig is the configuration of offline mode: an in-memory configuration without connection
+// loaded from a file of `set` lines and written back to the file after each commit.
+type offlineConfig struct {
+	mutex      sync.Mutex
+	file       string
+	permission os.FileMode
+	store      *setconfig.Store
+}
+
+// offlineDevice answers to the rpc about the device in offline mode
+// only with the facts of the configuration file and the hardware model.
+type offlineDevice struct {
+	model string
+}
+
+// newOfflineConfig loads the `set` lines of file (if exists) in a new in-memory configuration.
+// Empty lines and comments are skipped.
+func newOfflineConfig(file, model string, permission int64) (*offlineConfig, error) {
+	offline := &offlineConfig{
+		file:       file,
+		permission: os.FileMode(permission),
+		store:      setconfig.New(offlineDevice{model: model}),
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return offline, nil
+		}
+
+		return nil, fmt.Errorf("failed to open offline configuration file `%s` : %w", file, err)
+	}
+	defer f.Close()
+	lines := make([]string, 0)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "", strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "set "), strings.HasPrefix(line, "delete "):
+			lines = append(lines, line)
+		default:
+			return nil, fmt.Errorf("line %d of offline configuration file `%s` isn't a `set` or `delete` line: %q",
+				lineNumber, file, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read offline configuration file `%s` : %w", file, err)
+	}
+	offline.store.LoadCommitted(lines...)
+
+	return offline, nil
+}
+
+// dial opens a new netconf session on the in-memory configuration.
+func (o *offlineConfig) dial() (netconfTransport, error) {
+	return o.store.NewSession(), nil
+}
+
+// save writes the `set` lines of committed configuration in the file
+// (replaced with a rename of a temporary file to not leave a partial file).
+func (o *offlineConfig) save() error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	dir := filepath.Dir(o.file)
+	if _, err := os.Stat(dir); err != nil {
+		if err := os.MkdirAll(dir, directoryPermission); err != nil {
+			return fmt.Errorf("failed to create parent directory of `%s` : %w", o.file, err)
+		}
+	}
+	var config strings.Builder
+	for _, line := range o.store.Committed() {
+		config.WriteString(line + "\n")
+	}
+	tmpFile := o.file + ".tmp"
+	if err := os.WriteFile(tmpFile, []byte(config.String()), o.permission); err != nil {
+		return fmt.Errorf("failed to write offline configuration file `%s` : %w", tmpFile, err)
+	}
+	if err := os.Rename(tmpFile, o.file); err != nil {
+		return fmt.Errorf("failed to replace offline configuration file `%s` : %w", o.file, err)
+	}
+
+	return nil
+}
+
+// Operational returns the system information with the hardware model and the host-name of configuration
+// (the other facts are unknown) and the information of a physical interface (considered to exist,
+// with an unknown status). The other operational rpc return an error.
+func (o offlineDevice) Operational(name, rpc string, committed []string) (string, []setconfig.Error) {
+	switch name {
+	case "get-system-information":
+		info := "<system-information>" +
+			"<hardware-model>" + setconfig.Escape(o.model) + "</hardware-model>" +
+			"<os-name>junos</os-name>"
+		for _, line := range setconfig.FilterLines(committed, "system host-name", true) {
+			info += "<host-name>" + setconfig.Escape(strings.Trim(strings.TrimPrefix(line, "set "), "\"")) +
+				"</host-name>"
+		}
+
+		return info + "</system-information>", nil
+	case "get-interface-information":
+		var req struct {
+			InterfaceName string `xml:"interface-name"`
+		}
+		if err := xml.Unmarshal([]byte(rpc), &req); err != nil || req.InterfaceName == "" {
+			return "", setconfig.Errors(setconfig.SeverityError, "list of interfaces "+offlineNotAvailable)
+		}
+
+		return "<interface-information><physical-interface><name>" + setconfig.Escape(req.InterfaceName) +
+			"</name></physical-interface></interface-information>", nil
+	default:
+		return "", setconfig.Errors(setconfig.SeverityError, name+" "+offlineNotAvailable)
+	}
+}
+
+// CheckCommit accepts all configurations (there is no device to validate them).
+func (o offlineDevice) CheckCommit(_ []string) []setconfig.Error {
+	return nil
+}
+
+// Commit returns no result of routing engine (there is no routing engine).
+func (o offlineDevice) Commit(_ string, _, _ bool) string {
+	return ""
+}
@@ -0,0 +1,171 @@
+package junos
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestOfflineConfig(t *testing.T) {
+	offlineFile := filepath.Join(t.TempDir(), "site", "day0.set")
+	c := configProvider{
+		junosFilePermission:    "644",
+		junosOfflineConfigFile: offlineFile,
+		junosOfflineModel:      "vsrx",
+	}
+	sess, diags := c.prepareSession(context.Background())
+	if diags.HasError() {
+		t.Fatalf("prepareSession without file: %v", diags)
+	}
+	ctx := context.Background()
+	res := resourceRoutingInstance()
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"name": "offline",
+	})
+	if diags := res.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	config, err := os.ReadFile(offlineFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(config), "set routing-instances offline instance-type virtual-router\n") {
+		t.Errorf("instance not written in offline configuration file:\n%s", config)
+	}
+
+	// a previous render (or a saved configuration) is the source of truth of new session
+	if err := os.WriteFile(offlineFile, []byte(string(config)+"## comment\n\n"+
+		"set routing-options static route 192.0.2.0/24 next-hop 198.51.100.1\n"+
+		"set routing-options static route 192.0.2.0/24 next-hop 198.51.100.2\n"+
+		"set routing-options static route 192.0.2.0/24 qualified-next-hop 198.51.100.3 preference 10\n"+
+		"set routing-options static route 192.0.2.0/24 preference 5\n"+
+		"set routing-options static route 192.0.2.0/24 as-path path \"65000 65001\"\n"+
+		"set routing-options static route 192.0.2.0/24 as-path aggregator 65000 192.0.2.1\n"+
+		"set routing-options static route 192.0.2.0/24 no-readvertise\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sess, diags = c.prepareSession(context.Background())
+	if diags.HasError() {
+		t.Fatalf("prepareSession with file: %v", diags)
+	}
+	jnpr, err := sess.startNewSession(ctx)
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer sess.closeSession(jnpr)
+	route, err := readStaticRoute("192.0.2.0/24", defaultWord, sess, jnpr)
+	if err != nil {
+		t.Fatalf("readStaticRoute: %s", err)
+	}
+	if route.destination != "192.0.2.0/24" ||
+		len(route.nextHop) != 2 || route.nextHop[1] != "198.51.100.2" ||
+		len(route.qualifiedNextHop) != 1 || route.qualifiedNextHop[0]["preference"].(int) != 10 ||
+		route.preference != 5 ||
+		route.asPathPath != "65000 65001" ||
+		route.asPathAggregatorAsNumber != "65000" || route.asPathAggregatorAddress != "192.0.2.1" ||
+		!route.noReadvertise {
+		t.Errorf("unexpected static route read from offline configuration: %+v", route)
+	}
+	instanceExists, err := checkRoutingInstanceExists("offline", sess, jnpr)
+	if err != nil {
+		t.Fatalf("checkRoutingInstanceExists: %s", err)
+	}
+	if !instanceExists {
+		t.Errorf("instance of offline configuration file not found")
+	}
+
+	if err := os.WriteFile(offlineFile, []byte("deactivate system\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, diags := c.prepareSession(context.Background()); !diags.HasError() {
+		t.Errorf("offline configuration file with `deactivate` line accepted")
+	}
+}
+
+func TestOfflineConfigFacts(t *testing.T) {
+	offlineFile := filepath.Join(t.TempDir(), "day0.set")
+	c := configProvider{
+		junosFilePermission:    "644",
+		junosOfflineConfigFile: offlineFile,
+		junosOfflineModel:      "srx345",
+	}
+	sess, diags := c.prepareSession(context.Background())
+	if diags.HasError() {
+		t.Fatalf("prepareSession: %v", diags)
+	}
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	if info := jnpr.SystemInformation; info.HardwareModel != "srx345" || info.HostName != "" ||
+		info.OsVersion != "" || info.SerialNumber != "" {
+		t.Errorf("unexpected system information without host-name in file: %+v", info)
+	}
+	if exists, err := checkInterfaceExists("ge-0/0/7", sess, jnpr); err != nil || !exists {
+		t.Errorf("physical interface not considered to exist: %t, %v", exists, err)
+	}
+	if _, err := sess.commandXML(rpcGetInterfaceInformationTerse, jnpr); err == nil ||
+		!strings.Contains(err.Error(), "offline mode") {
+		t.Errorf("unexpected error for the list of interfaces: %v", err)
+	}
+	sess.closeSession(jnpr)
+
+	if err := os.WriteFile(offlineFile, []byte("set system host-name \"site 1\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	sess, diags = c.prepareSession(context.Background())
+	if diags.HasError() {
+		t.Fatalf("prepareSession with file: %v", diags)
+	}
+	jnpr, err = sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession with file: %s", err)
+	}
+	defer sess.closeSession(jnpr)
+	if jnpr.SystemInformation.HostName != "site 1" {
+		t.Errorf("unexpected host-name %q", jnpr.SystemInformation.HostName)
+	}
+}
+
+func TestOfflineConfigStaticRouteAggregator(t *testing.T) {
+	c := configProvider{
+		junosFilePermission:    "644",
+		junosOfflineConfigFile: filepath.Join(t.TempDir(), "day0.set"),
+		junosOfflineModel:      "vsrx",
+	}
+	sess, diags := c.prepareSession(context.Background())
+	if diags.HasError() {
+		t.Fatalf("prepareSession: %v", diags)
+	}
+	ctx := context.Background()
+	res := resourceStaticRoute()
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"destination":                  "192.0.2.0/24",
+		"as_path_aggregator_as_number": "65000",
+		"as_path_aggregator_address":   "192.0.2.1",
+		"next_hop":                     []interface{}{"198.51.100.1"},
+	})
+	if diags := res.CreateContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+
+	// read with a new session on the rendered file
+	sess, diags = c.prepareSession(context.Background())
+	if diags.HasError() {
+		t.Fatalf("prepareSession with rendered file: %v", diags)
+	}
+	if diags := res.ReadContext(ctx, d, sess); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatalf("static route not found in rendered file")
+	}
+	if asNumber, address := d.Get("as_path_aggregator_as_number").(string),
+		d.Get("as_path_aggregator_address").(string); asNumber != "65000" || address != "192.0.2.1" {
+		t.Errorf("unexpected as-path aggregator %q %q after read", asNumber, address)
+	}
+}
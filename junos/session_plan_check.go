@@ -0,0 +1,229 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jeremmfr/go-netconf/netconf"
+)
+
+// renderSetFile replaces the path of fake set file on session which renders the lines of resources.
+const renderSetFile = "plan commit check"
+
+var errRenderConnection = errors.New("no connection to device to render lines") // nolint: gochecknoglobals
+
+// planCommitCheckSkip are the resources without lines to check at plan.
+var planCommitCheckSkip = map[string]bool{ // nolint: gochecknoglobals
+	"junos_commit":           true,
+	"junos_null_commit_file": true,
+}
+
+// addResourcePlanCommitCheck adds a CustomizeDiff on resource to check its lines
+// with a `commit check` during plan (when enabled on provider).
+func addResourcePlanCommitCheck(name string, resource *schema.Resource) {
+	if planCommitCheckSkip[name] {
+		return
+	}
+	customizeDiff := resource.CustomizeDiff
+	resource.CustomizeDiff = func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		if customizeDiff != nil {
+			if err := customizeDiff(ctx, diff, m); err != nil {
+				return err
+			}
+		}
+		sess, ok := m.(*Session)
+		if !ok || !sess.junosPlanCommitCheck {
+			return nil
+		}
+		if sess.junosFakeCreateSetFile != "" {
+			sess.planCommitCheckSkipped(ctx, name, "fake_create_with_setfile enabled")
+
+			return nil
+		}
+
+		return sess.planCommitCheck(ctx, name, resource, diff)
+	}
+}
+
+// planCommitCheckWarnings collects the reasons of skipped plan commit checks during the plan of a resource
+// to return them as warnings of plan.
+type planCommitCheckWarnings struct {
+	reasons []string
+}
+
+type planCommitCheckWarningsKey struct{}
+
+// withPlanCommitCheckWarnings returns a context which collects the reasons of skipped plan commit checks in w.
+func withPlanCommitCheckWarnings(ctx context.Context, w *planCommitCheckWarnings) context.Context {
+	return context.WithValue(ctx, planCommitCheckWarningsKey{}, w)
+}
+
+// planCommitCheckSkipped logs the reason of a skipped plan commit check and adds it to the warnings of plan
+// collected in context (if any).
+func (sess *Session) planCommitCheckSkipped(ctx context.Context, name, reason string) {
+	sess.logFile(fmt.Sprintf("[planCommitCheck] %s skipped: %s", name, reason))
+	w, ok := ctx.Value(planCommitCheckWarningsKey{}).(*planCommitCheckWarnings)
+	if !ok {
+		return
+	}
+	warning := fmt.Sprintf("commit check at plan of %s skipped: %s", name, reason)
+	for _, v := range w.reasons {
+		if v == warning {
+			return
+		}
+	}
+	w.reasons = append(w.reasons, warning)
+}
+
+// renderSession returns a session without connection to device (with the same configuration prefix),
+// which renders in lines the `set` and `delete` lines of resources with their branch of `fake_create_with_setfile`.
+func (sess *Session) renderSession(lines *[]string) *Session {
+	render := *sess.sessionShared
+	render.pool = nil
+	render.deferred = nil
+	render.cache = nil
+	render.render = lines
+	render.junosFakeCreateSetFile = renderSetFile
+	render.junosFakeUpdateAlso = false
+	render.junosFakeDeleteAlso = true
+	render.dialTransport = func() (netconfTransport, error) {
+		return nil, errRenderConnection
+	}
+
+	return &Session{sessionShared: &render, prefix: sess.prefix}
+}
+
+// planCommitCheck renders the lines of resource with the planned values (`delete` lines of
+// the current values first for an update), loads them in a private candidate configuration,
+// runs a `commit check` and discards the private candidate configuration.
+// Errors of device are returned with the attribute of resource which generates the bad element if found.
+// The check is skipped when values are unknown at plan or lines can't be rendered without device,
+// with a warning of plan.
+func (sess *Session) planCommitCheck(
+	ctx context.Context, name string, resource *schema.Resource, diff *schema.ResourceDiff) error {
+	if diff.Id() != "" && len(diff.GetChangedKeysPrefix("")) == 0 {
+		return nil
+	}
+	if rawConfig := diff.GetRawConfig(); !rawConfig.IsNull() && !rawConfig.IsWhollyKnown() {
+		sess.planCommitCheckSkipped(ctx, name, "values unknown at plan")
+
+		return nil
+	}
+	planned := resource.Data(nil)
+	current := resource.Data(nil)
+	for key := range resource.Schema {
+		if !diff.NewValueKnown(key) {
+			sess.planCommitCheckSkipped(ctx, name, key+" unknown at plan")
+
+			return nil
+		}
+		oldValue, newValue := diff.GetChange(key)
+		if err := planned.Set(key, newValue); err != nil {
+			sess.planCommitCheckSkipped(ctx, name, err.Error())
+
+			return nil
+		}
+		if diff.Id() != "" {
+			if err := current.Set(key, oldValue); err != nil {
+				sess.planCommitCheckSkipped(ctx, name, err.Error())
+
+				return nil
+			}
+		}
+	}
+	lines := make([]string, 0)
+	render := sess.renderSession(&lines)
+	if diff.Id() != "" {
+		current.SetId(diff.Id())
+		if diags := resource.DeleteContext(ctx, current, render); diags.HasError() {
+			// resource without fake delete, check only the new lines
+			lines = lines[:0]
+		}
+	}
+	if diags := resource.CreateContext(ctx, planned, render); diags.HasError() {
+		sess.planCommitCheckSkipped(ctx, name, "lines can't be rendered without device")
+
+		return nil
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	jnpr, err := sess.startNewSession(ctx)
+	if err != nil {
+		sess.planCommitCheckSkipped(ctx, name, err.Error())
+
+		return nil
+	}
+	defer sess.closeSession(jnpr)
+	if err := jnpr.netconfConfigOpenPrivate(); err != nil {
+		sess.planCommitCheckSkipped(ctx, name, "open private configuration: "+err.Error())
+
+		return nil
+	}
+	defer func() {
+		for _, err := range jnpr.netconfConfigCloseConfig() {
+			sess.logFile(fmt.Sprintf("[planCommitCheck] close configuration err: %q", err))
+		}
+	}()
+	sess.logFile(fmt.Sprintf("[planCommitCheck] %s lines: %q", name, lines))
+	if _, err := jnpr.netconfConfigSet(lines); err != nil {
+		return planCommitCheckError(name, planned, resource, err)
+	}
+	if _, err := jnpr.netconfCommitCheck(); err != nil {
+		return planCommitCheckError(name, planned, resource, err)
+	}
+
+	return nil
+}
+
+// planCommitCheckError returns the error of plan commit check with the attribute of resource
+// which has the bad element of error as value (if found).
+func planCommitCheckError(name string, d *schema.ResourceData, resource *schema.Resource, err error) error {
+	var rpcErr *netconf.RPCError
+	if errors.As(err, &rpcErr) {
+		badElement := strings.Trim(strings.TrimSpace(rpcErr.BadElement), "\"")
+		keys := make([]string, 0, len(resource.Schema))
+		for key := range resource.Schema {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if badElement != "" && valueContains(d.Get(key), badElement) {
+				return fmt.Errorf("commit check at plan of %s failed on attribute %q: %w", name, key, err)
+			}
+		}
+	}
+
+	return fmt.Errorf("commit check at plan of %s failed: %w", name, err)
+}
+
+// valueContains returns true if value (or a value in its nested blocks) is equal to element.
+func valueContains(value interface{}, element string) bool {
+	switch v := value.(type) {
+	case string:
+		return v == element
+	case int:
+		return strconv.Itoa(v) == element
+	case []interface{}:
+		for _, e := range v {
+			if valueContains(e, element) {
+				return true
+			}
+		}
+	case *schema.Set:
+		return valueContains(v.List(), element)
+	case map[string]interface{}:
+		for _, e := range v {
+			if valueContains(e, element) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package junos
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestPlanCommitCheck(t *testing.T) {
+	sess, device := newFakeDeviceSession(t, "vsrx")
+	sess.junosPlanCommitCheck = true
+	device.RejectStatement("bad-description", "description not allowed")
+	res := Provider().ResourcesMap["junos_routing_instance"]
+
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":        "test",
+		"description": "good-description",
+	})
+	if _, err := res.Diff(context.Background(), nil, config, sess); err != nil {
+		t.Fatalf("plan with valid configuration: %s", err)
+	}
+
+	config = terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":        "test",
+		"description": "bad-description",
+	})
+	_, err := res.Diff(context.Background(), nil, config, sess)
+	if err == nil {
+		t.Fatal("plan with configuration rejected by device without error")
+	}
+	if !strings.Contains(err.Error(), `attribute "description"`) ||
+		!strings.Contains(err.Error(), "description not allowed") {
+		t.Errorf("unexpected error %q", err)
+	}
+
+	if committed := device.Committed(); len(committed) != 0 {
+		t.Errorf("configuration committed at plan: %q", committed)
+	}
+	if candidate := device.Candidate(); len(candidate) != 0 {
+		t.Errorf("shared candidate configuration modified at plan: %q", candidate)
+	}
+}
+
+func TestPlanCommitCheckSkippedWarning(t *testing.T) {
+	sess, _ := newFakeDeviceSession(t, "vsrx")
+	sess.junosPlanCommitCheck = true
+	res := Provider().ResourcesMap["junos_routing_instance"]
+	warnings := &planCommitCheckWarnings{}
+	ctx := withPlanCommitCheckWarnings(context.Background(), warnings)
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":        "test",
+		"description": "74D93920-ED26-11E3-AC10-0800200C9A66", // unknown value in ResourceConfig
+	})
+	if _, err := res.Diff(ctx, nil, config, sess); err != nil {
+		t.Fatalf("plan with unknown value: %s", err)
+	}
+	if len(warnings.reasons) != 1 ||
+		warnings.reasons[0] != "commit check at plan of junos_routing_instance skipped: description unknown at plan" {
+		t.Errorf("unexpected warnings %q", warnings.reasons)
+	}
+}
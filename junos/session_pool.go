@@ -0,0 +1,159 @@
+package junos
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	rpcPoolHealthCheck = "<get-system-uptime-information/>"
+
+	// poolIdleHealthCheck is the idle duration after which a pooled session is checked before re-use.
+	poolIdleHealthCheck = 5 * time.Second
+)
+
+// sessionPool keeps authenticated netconf sessions open to re-use them between resources.
+type sessionPool struct {
+	closed bool
+	size   int
+	opened int
+	mutex  sync.Mutex
+	idle   []*pooledNetconfObject
+	wait   chan struct{}
+}
+
+type pooledNetconfObject struct {
+	jnpr     *NetconfObject
+	lastUsed time.Time
+}
+
+func newSessionPool(size int) *sessionPool {
+	return &sessionPool{
+		size: size,
+		idle: make([]*pooledNetconfObject, 0, size),
+		wait: make(chan struct{}, size),
+	}
+}
+
+// get returns an idle healthy session or open a new session if pool is not full,
+// otherwise wait for a released session.
+func (pool *sessionPool) get(ctx context.Context, sess *Session) (*NetconfObject, error) {
+	for {
+		pool.mutex.Lock()
+		if pool.closed {
+			pool.mutex.Unlock()
+			// wake up the next waiter to return it the same error
+			pool.signal()
+
+			return nil, fmt.Errorf("netconf session pool is closed")
+		}
+		if len(pool.idle) > 0 {
+			pooled := pool.idle[len(pool.idle)-1]
+			pool.idle = pool.idle[:len(pool.idle)-1]
+			pool.mutex.Unlock()
+			if time.Since(pooled.lastUsed) < poolIdleHealthCheck || pooled.jnpr.healthCheck() {
+				sess.logFile("[startNewSession] re-use session from pool")
+
+				return pooled.jnpr, nil
+			}
+			sess.logFile("[startNewSession] session from pool is unhealthy, reconnect")
+			pooled.jnpr.closeTransport()
+			pool.release()
+
+			continue
+		}
+		if pool.opened < pool.size {
+			pool.opened++
+			pool.mutex.Unlock()
+			jnpr, err := sess.openNetconfObject(ctx)
+			if err != nil {
+				if jnpr != nil {
+					jnpr.closeTransport()
+				}
+				pool.release()
+
+				return nil, err
+			}
+
+			return jnpr, nil
+		}
+		pool.mutex.Unlock()
+		select {
+		case <-pool.wait:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("no netconf session available in pool : %w", ctx.Err())
+		}
+	}
+}
+
+// put returns a session in pool to be re-used.
+func (pool *sessionPool) put(jnpr *NetconfObject) {
+	pool.mutex.Lock()
+	if pool.closed {
+		pool.mutex.Unlock()
+		jnpr.closeTransport()
+
+		return
+	}
+	pool.idle = append(pool.idle, &pooledNetconfObject{jnpr: jnpr, lastUsed: time.Now()})
+	pool.mutex.Unlock()
+	pool.signal()
+}
+
+// discard closes a session that can't be re-used and free its place in pool.
+func (pool *sessionPool) discard(jnpr *NetconfObject) {
+	jnpr.closeTransport()
+	pool.release()
+}
+
+func (pool *sessionPool) release() {
+	pool.mutex.Lock()
+	pool.opened--
+	pool.mutex.Unlock()
+	pool.signal()
+}
+
+func (pool *sessionPool) signal() {
+	select {
+	case pool.wait <- struct{}{}:
+	default:
+	}
+}
+
+// closeAll closes idle sessions and forbid new sessions (waiters get an error
+// and sessions in use are closed when they are released).
+func (pool *sessionPool) closeAll(sleepClosed int) {
+	pool.mutex.Lock()
+	pool.closed = true
+	idle := pool.idle
+	pool.idle = make([]*pooledNetconfObject, 0)
+	pool.opened -= len(idle)
+	pool.mutex.Unlock()
+	for _, pooled := range idle {
+		_ = pooled.jnpr.close(sleepClosed)
+	}
+	pool.signal()
+}
+
+// healthCheck runs a light rpc to detect a broken session.
+func (j *NetconfObject) healthCheck() bool {
+	reply, err := j.execCleanup(rpcPoolHealthCheck)
+	if err != nil {
+		return false
+	}
+	if reply.Errors != nil {
+		return false
+	}
+
+	return true
+}
+
+// closeTransport closes the transport without waiting a reply of device.
+func (j *NetconfObject) closeTransport() {
+	j.closed = true
+	if j.transport != nil {
+		j.transport.Close()
+	}
+}
@@ -0,0 +1,57 @@
+package junos
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestSessionPoolReuse(t *testing.T) {
+	pool := newSessionPool(1)
+	defer pool.closeAll(0)
+	jnpr := &NetconfObject{}
+	pool.opened = 1
+	pool.put(jnpr)
+	for i := 0; i < 3; i++ {
+		pooled, err := pool.get(context.Background(), &Session{sessionShared: &sessionShared{}})
+		if err != nil {
+			t.Fatalf("get: %s", err)
+		}
+		if pooled != jnpr {
+			t.Fatal("idle session of pool not re-used")
+		}
+		if i < 2 {
+			pool.put(pooled)
+		}
+	}
+}
+
+func TestSessionPoolCloseAll(t *testing.T) {
+	pool := newSessionPool(1)
+	// the only session of pool is in use
+	pool.opened = 1
+	waitErrs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := pool.get(context.Background(), &Session{sessionShared: &sessionShared{}})
+			waitErrs <- err
+		}()
+	}
+	// let waiters wait for the session in use
+	time.Sleep(100 * time.Millisecond)
+	pool.closeAll(0)
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-waitErrs:
+			if err == nil {
+				t.Error("session returned by a closed pool")
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("waiter not woken up when pool is closed")
+		}
+	}
+	pool.put(&NetconfObject{})
+	if len(pool.idle) != 0 {
+		t.Error("session in use kept idle when released in a closed pool")
+	}
+}
@@ -0,0 +1,106 @@
+package junos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// retry runs an operation on session and, while the operation fails with a transport error,
+// waits a backoff, reconnects the session (and resumes the pending changes) and runs the
+// operation again until the number of attempts is reached.
+// The operation need to give the same result when it is replayed (reads, lock, load of set lines
+// already loaded), a commit need to be run with commitResume to check if the lost commit has been applied.
+func (sess *Session) retry(jnpr *NetconfObject, operation string, run func() error) error {
+	err := run()
+	for attempt := 1; attempt <= sess.junosRetryAttempts && isTransportError(err); attempt++ {
+		sess.logFile(fmt.Sprintf("[retry] %s failed, retry %d/%d: %q", operation, attempt, sess.junosRetryAttempts, err))
+		if errSleep := sleepContext(jnpr.ctx, sess.retryBackoff(attempt)); errSleep != nil {
+			return err
+		}
+		if err = sess.reconnect(jnpr); err != nil {
+			sess.logFile(fmt.Sprintf("[retry] reconnect failed: %q", err))
+
+			continue
+		}
+		err = run()
+	}
+
+	return err
+}
+
+// retryBackoff returns the duration to wait before the attempt, doubled at each attempt.
+func (sess *Session) retryBackoff(attempt int) time.Duration {
+	return time.Duration(sess.junosRetryBackoff) * time.Second << (attempt - 1)
+}
+
+// reconnect replaces the broken netconf session of jnpr with a new session.
+// If the candidate configuration was locked, it locks again the candidate configuration and
+// loads the pending lines (not yet committed) to resume the changes lost with the broken session.
+func (sess *Session) reconnect(jnpr *NetconfObject) error {
+	jnpr.closeTransport()
+	newJnpr, err := sess.dialNetconfFacts(jnpr.ctx)
+	if err != nil {
+		if newJnpr != nil {
+			newJnpr.closeTransport()
+		}
+
+		return err
+	}
+	jnpr.transport = newJnpr.transport
+	jnpr.SystemInformation = newJnpr.SystemInformation
+	jnpr.closed = false
+	sess.logFile("[reconnect] new session opened")
+	if !jnpr.locked {
+		return nil
+	}
+	jnpr.locked = false
+	if err := sess.waitConfigLock(jnpr); err != nil {
+		return err
+	}
+	if sess.junosConfigMode != configModePrivate {
+		// discard changes possibly left by the broken session
+		for _, errClear := range jnpr.netconfConfigClear() {
+			sess.logFile(fmt.Sprintf("[reconnect] config clear err: %q", errClear))
+		}
+	}
+	if len(jnpr.pending) > 0 {
+		message, err := jnpr.netconfConfigSet(jnpr.pending)
+		sleepShort(sess.junosSleepShort)
+		sess.logFile(fmt.Sprintf("[reconnect] reload pending lines: %q", jnpr.pending))
+		if err != nil {
+			return fmt.Errorf("failed to reload pending lines after reconnect : %w", err)
+		}
+		if message != "" {
+			sess.logFile(fmt.Sprintf("[reconnect] reload message: %q", message))
+		}
+	}
+
+	return nil
+}
+
+// dialNetconfFacts connects to the device, gathers facts and returns a transport error
+// if the connection failed because of network.
+func (sess *Session) dialNetconfFacts(ctx context.Context) (*NetconfObject, error) {
+	jnpr, err := sess.dialNetconf()
+	if err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) {
+			return nil, &transportError{err: err}
+		}
+
+		return nil, err
+	}
+	jnpr.ctx = ctx
+	jnpr.rpcTimeout = time.Duration(sess.junosRPCTimeout) * time.Second
+	if err := jnpr.gatherFacts(); err != nil {
+		return jnpr, err
+	}
+	if jnpr.SystemInformation.HardwareModel == "" {
+		return jnpr, fmt.Errorf("can't read model of device with <get-system-information/> netconf command")
+	}
+
+	return jnpr, nil
+}
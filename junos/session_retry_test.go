@@ -0,0 +1,106 @@
+package junos
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// countRequests returns the number of requests received by stub with rpc.
+func countRequests(stub *tlsNetconfStub, rpc string) int {
+	count := 0
+	for _, request := range stub.received() {
+		if strings.Contains(request, rpc) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func TestSessionRetryCommand(t *testing.T) {
+	sess, stub := newTLSStubSession(t)
+	stub.dropOnce = "show version"
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer jnpr.closeTransport()
+	read, err := sess.command("show version", jnpr)
+	if err != nil {
+		t.Fatalf("command not retried after connection lost: %s", err)
+	}
+	if read != "command output" {
+		t.Errorf("unexpected output %q", read)
+	}
+}
+
+func TestSessionRetryCommitResume(t *testing.T) {
+	sess, stub := newTLSStubSession(t)
+	stub.dropOnce = "<commit-configuration>"
+	// the dropped commit hasn't been applied
+	stub.compare = "[edit system]\n+  host-name stub;"
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer jnpr.closeTransport()
+	if err := sess.configLock(jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	if err := sess.configSet([]string{"set system host-name stub"}, jnpr); err != nil {
+		t.Fatalf("configSet: %s", err)
+	}
+	if _, err := sess.commitConf("test", jnpr); err != nil {
+		t.Fatalf("commit not resumed after connection lost: %s", err)
+	}
+	// after the dropped commit: new session, lock, clear, reload pending lines and commit again
+	requests := stub.received()
+	var resumed []string
+	for i, request := range requests {
+		if strings.Contains(request, "<commit-configuration>") {
+			resumed = requests[i+1:]
+
+			break
+		}
+	}
+	expected := []string{rpcSystemInfo, rpcCandidateLock, rpcClearCandidate, "set system host-name stub",
+		rpcGetCompareRollback, "<commit-configuration>"}
+	if len(resumed) < len(expected) {
+		t.Fatalf("unexpected requests after dropped commit: %q", resumed)
+	}
+	for i, v := range expected {
+		if !strings.Contains(resumed[i], v) {
+			t.Errorf("request %d after dropped commit doesn't contain %q: %q", i, v, resumed[i])
+		}
+	}
+	if len(jnpr.pending) != 0 {
+		t.Errorf("pending lines not reset after commit: %q", jnpr.pending)
+	}
+}
+
+func TestSessionRetryCommitAlreadyApplied(t *testing.T) {
+	sess, stub := newTLSStubSession(t)
+	stub.dropOnce = "<commit-configuration>"
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer jnpr.closeTransport()
+	if err := sess.configLock(jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	if err := sess.configSet([]string{"set system host-name stub"}, jnpr); err != nil {
+		t.Fatalf("configSet: %s", err)
+	}
+	// the device has applied the commit before the loss of session: no differences after reload
+	if _, err := sess.commitConf("test", jnpr); err != nil {
+		t.Fatalf("commit not resumed after connection lost: %s", err)
+	}
+	if count := countRequests(stub, "<commit-configuration>"); count != 1 {
+		t.Errorf("commit replayed after the loss of session while already applied (%d commits)", count)
+	}
+	if count := countRequests(stub, rpcGetCompareRollback); count != 1 {
+		t.Errorf("%d compare before replay of commit, want 1", count)
+	}
+}
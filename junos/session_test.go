@@ -0,0 +1,184 @@
+package junos
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jeremmfr/go-netconf/netconf"
+	"github.com/jeremmfr/terraform-provider-junos/junos/internal/fakedevice"
+)
+
+// newFakeDeviceSession returns a session of provider connected to a new in-memory fake device.
+func newFakeDeviceSession(t *testing.T, model string) (*Session, *fakedevice.Device) {
+	t.Helper()
+	device := fakedevice.New(model)
+	c := configProvider{
+		junosIP:             "127.0.0.1",
+		junosFilePermission: "644",
+	}
+	sess, diags := c.prepareSession(context.Background())
+	if diags.HasError() {
+		t.Fatalf("prepareSession: %v", diags)
+	}
+	sess.dialTransport = func() (netconfTransport, error) {
+		return device.NewSession(), nil
+	}
+
+	return sess, device
+}
+
+func TestPrepareSessionConfigMode(t *testing.T) {
+	c := configProvider{
+		junosIP:             "127.0.0.1",
+		junosFilePermission: "644",
+		junosConfigMode:     configModePrivate,
+		junosCmdLockMaxWait: 5,
+	}
+	sess, diags := c.prepareSession(context.Background())
+	if diags.HasError() {
+		t.Fatalf("prepareSession: %v", diags)
+	}
+	if sess.junosConfigMode != configModePrivate || sess.junosLockMaxWait != 5 {
+		t.Errorf("config_mode %q and cmd_lock_max_wait %d not passed to session",
+			sess.junosConfigMode, sess.junosLockMaxWait)
+	}
+}
+
+func TestFakeDeviceConfigLocked(t *testing.T) {
+	sess, device := newFakeDeviceSession(t, "vsrx")
+	sess.junosLockMaxWait = 1
+	sess.junosSleepLock = 1
+	other := device.NewSession()
+	if _, err := other.Exec(netconf.RawMethod(rpcCandidateLock)); err != nil {
+		t.Fatalf("lock with other session: %s", err)
+	}
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer sess.closeSession(jnpr)
+	if err := sess.configLock(jnpr); err == nil {
+		t.Fatal("candidate configuration locked while locked by another session")
+	}
+	other.Close()
+	if err := sess.configLock(jnpr); err != nil {
+		t.Fatalf("lock after release by other session: %s", err)
+	}
+	if err := sess.configSet([]string{"set system host-name test"}, jnpr); err != nil {
+		t.Fatalf("configSet: %s", err)
+	}
+	if _, err := sess.commitConf("test", jnpr); err != nil {
+		t.Fatalf("commitConf: %s", err)
+	}
+	if jnpr.SystemInformation.HardwareModel != device.Model() {
+		t.Errorf("unexpected hardware model %q", jnpr.SystemInformation.HardwareModel)
+	}
+}
+
+func TestFakeDeviceShowCompare(t *testing.T) {
+	sess, device := newFakeDeviceSession(t, "vsrx")
+	device.LoadCommitted("set system host-name old")
+	sess.junosShowCompare = true
+	sess.junosCompareFile = filepath.Join(t.TempDir(), "netconf_compare.diff")
+	jnpr, err := sess.startNewSession(context.Background())
+	if err != nil {
+		t.Fatalf("startNewSession: %s", err)
+	}
+	defer sess.closeSession(jnpr)
+	if err := sess.configLock(jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	if err := sess.configSet([]string{"delete system host-name", "set system host-name new"}, jnpr); err != nil {
+		t.Fatalf("configSet: %s", err)
+	}
+	warns, err := sess.commitConf("test compare", jnpr)
+	if err != nil {
+		t.Fatalf("commitConf: %s", err)
+	}
+	if len(warns) != 1 {
+		t.Fatalf("unexpected warnings %q", warns)
+	}
+	for _, expected := range []string{"-  system host-name old", "+  system host-name new"} {
+		if !strings.Contains(warns[0].Error(), expected) {
+			t.Errorf("%q not in warning %q", expected, warns[0])
+		}
+	}
+	compareFile, err := os.ReadFile(sess.junosCompareFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(compareFile), "commit \"test compare\"") ||
+		!strings.Contains(string(compareFile), "+  system host-name new") {
+		t.Errorf("unexpected compare file:\n%s", compareFile)
+	}
+
+	// without change, no warning
+	if err := sess.configLock(jnpr); err != nil {
+		t.Fatalf("configLock: %s", err)
+	}
+	warns, err = sess.commitConf("test without change", jnpr)
+	if err != nil {
+		t.Fatalf("commitConf: %s", err)
+	}
+	if len(warns) != 0 {
+		t.Errorf("unexpected warnings without change %q", warns)
+	}
+}
+
+func TestFakeDeviceCommitSynchronize(t *testing.T) {
+	commit := func(sess *Session, logMessage string) []error {
+		t.Helper()
+		jnpr, err := sess.startNewSession(context.Background())
+		if err != nil {
+			t.Fatalf("startNewSession: %s", err)
+		}
+		defer sess.closeSession(jnpr)
+		if err := sess.configLock(jnpr); err != nil {
+			t.Fatalf("configLock: %s", err)
+		}
+		if err := sess.configSet([]string{"set system host-name " + logMessage}, jnpr); err != nil {
+			t.Fatalf("configSet: %s", err)
+		}
+		warns, err := sess.commitConf(logMessage, jnpr)
+		if err != nil {
+			t.Fatalf("commitConf: %s", err)
+		}
+
+		return warns
+	}
+
+	sess, device := newFakeDeviceSession(t, "mx240")
+	device.SetRouteEngines(2)
+	if warns := commit(sess, "first"); len(warns) != 1 || !strings.Contains(warns[0].Error(), "2 routing engines") {
+		t.Errorf("unexpected warnings on dual routing engines without synchronize %q", warns)
+	}
+	if warns := commit(sess, "second"); len(warns) != 0 {
+		t.Errorf("unexpected warnings on second commit %q", warns)
+	}
+	if synchronized := device.SynchronizedCommits(); len(synchronized) != 0 {
+		t.Errorf("unexpected synchronized commits %q", synchronized)
+	}
+	sess.junosCommitSynchronize = true
+	if warns := commit(sess, "synchronized"); len(warns) != 0 {
+		t.Errorf("unexpected warnings with synchronize %q", warns)
+	}
+	if synchronized := device.SynchronizedCommits(); len(synchronized) != 1 || synchronized[0] != "synchronized" {
+		t.Errorf("unexpected synchronized commits %q", synchronized)
+	}
+
+	sess, device = newFakeDeviceSession(t, "srx345")
+	device.SetClusterNode(true)
+	if warns := commit(sess, "cluster"); len(warns) != 1 || !strings.Contains(warns[0].Error(), "chassis cluster") {
+		t.Errorf("unexpected warnings on chassis cluster without synchronize %q", warns)
+	}
+
+	sess, device = newFakeDeviceSession(t, "srx345")
+	device.SetClusterNode(true)
+	device.LoadCommitted("set system commit synchronize")
+	if warns := commit(sess, "configured"); len(warns) != 0 {
+		t.Errorf("unexpected warnings with synchronize configured on device %q", warns)
+	}
+}
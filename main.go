@@ -8,6 +8,7 @@ import (
 
 func main() {
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: junos.Provider,
+		GRPCProviderFunc: junos.ProviderServer,
 	})
+	junos.CloseSessions()
 }